@@ -2,17 +2,37 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
+// configFileEnv points to optional YAML file with the same keys as env variables without prefix, e.g. db_host,
+// env variables take precedence over the file
+const configFileEnv = "ORDER_CONFIG_FILE"
+
+var errInvalidConfig = errors.New("invalid config")
+
 func parseEnv() (*config, error) {
+	if path := os.Getenv(configFileEnv); path != "" {
+		if err := applyConfigFile(path); err != nil {
+			return nil, err
+		}
+	}
+
 	c := new(config)
 	if err := envconfig.Process(appID, c); err != nil {
 		return nil, errors.Wrap(err, "failed to parse env")
 	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -21,6 +41,9 @@ type config struct {
 
 	ServeGRPCAddress string `envconfig:"serve_grpc_address" default:":8081"`
 
+	HealthCheckTimeout time.Duration `envconfig:"health_check_timeout" default:"5s"`
+	ShutdownTimeout    time.Duration `envconfig:"shutdown_timeout" default:"30s"`
+
 	DBHost     string `envconfig:"db_host" default:"localhost"`
 	DBPort     string `envconfig:"db_port"`
 	DBName     string `envconfig:"db_name"`
@@ -28,9 +51,53 @@ type config struct {
 	DBPassword string `envconfig:"db_password"`
 	DBMaxConn  int    `envconfig:"db_max_conn"`
 
+	DBMaxIdleConn     int           `envconfig:"db_max_idle_conn" default:"2"`
+	DBConnMaxLifetime time.Duration `envconfig:"db_conn_max_lifetime" default:"5m"`
+	DBConnMaxIdleTime time.Duration `envconfig:"db_conn_max_idle_time" default:"1m"`
+	DBPingTimeout     time.Duration `envconfig:"db_ping_timeout" default:"5s"`
+
 	TestGRPCAddress string `envconfig:"test_grpc_address" default:"test:8081"`
 }
 
+func (c *config) validate() error {
+	var problems []string
+	if _, err := log.ParseLevel(c.LogLevel); err != nil {
+		problems = append(problems, fmt.Sprintf("unknown log_level %q", c.LogLevel))
+	}
+	for name, value := range map[string]string{
+		"db_port": c.DBPort,
+		"db_name": c.DBName,
+		"db_user": c.DBUser,
+	} {
+		if value == "" {
+			problems = append(problems, name+" is required")
+		}
+	}
+	for name, value := range map[string]int{
+		"db_max_conn":      c.DBMaxConn,
+		"db_max_idle_conn": c.DBMaxIdleConn,
+	} {
+		if value < 0 {
+			problems = append(problems, name+" must not be negative")
+		}
+	}
+	for name, value := range map[string]time.Duration{
+		"health_check_timeout": c.HealthCheckTimeout,
+		"db_ping_timeout":      c.DBPingTimeout,
+		"shutdown_timeout":     c.ShutdownTimeout,
+	} {
+		if value <= 0 {
+			problems = append(problems, name+" must be positive")
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.Wrap(errInvalidConfig, strings.Join(problems, "; "))
+}
+
 func (c *config) buildDSN() string {
 	return fmt.Sprintf(
 		"%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=%s",
@@ -42,3 +109,27 @@ func (c *config) buildDSN() string {
 		time.UTC.String(),
 	)
 }
+
+// applyConfigFile exports values from the file as env variables which are not set yet
+func applyConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read config file")
+	}
+
+	values := make(map[string]string)
+	if err = yaml.Unmarshal(data, &values); err != nil {
+		return errors.Wrapf(err, "failed to parse config file %s", path)
+	}
+
+	for key, value := range values {
+		name := strings.ToUpper(appID + "_" + key)
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+		if err = os.Setenv(name, value); err != nil {
+			return errors.Wrapf(err, "failed to apply %s from config file", key)
+		}
+	}
+	return nil
+}
@@ -1,29 +1,43 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/health"
 )
 
 type multiCloser struct {
+	mu      sync.Mutex
 	closers []io.Closer
 }
 
 func (m *multiCloser) Add(c io.Closer) {
 	if c != nil {
+		m.mu.Lock()
+		defer m.mu.Unlock()
 		m.closers = append(m.closers, c)
 	}
 }
 
+// Close closes in registration order, closers are released so repeated Close is a no-op.
+// It is safe to call while an abandoned shutdown step is still closing
 func (m *multiCloser) Close() error {
+	m.mu.Lock()
+	closers := m.closers
+	m.closers = nil
+	m.mu.Unlock()
+
 	var errs []error
-	for _, c := range m.closers {
+	for _, c := range closers {
 		if err := c.Close(); err != nil {
 			errs = append(errs, err)
 		}
@@ -41,20 +55,22 @@ func newConnectionsContainer(
 	multiCloser *multiCloser,
 ) (container *connectionsContainer, err error) {
 	containerBuilder := func() error {
-		container = &connectionsContainer{}
+		container = &connectionsContainer{
+			healthChecker: health.NewChecker(config.HealthCheckTimeout),
+		}
 
 		db, err := initMySQL(config)
 		if err != nil {
 			return fmt.Errorf("failed to init DB for migrations: %w", err)
 		}
-		defer db.Close()
+		multiCloser.Add(db)
 
 		if err = applyMigrations(db.DB, pathToMigrations); err != nil {
 			return fmt.Errorf("migration failed: %w", err)
 		}
 		log.Infof("Migrations applied successfully")
-		multiCloser.Add(db)
 		container.db = db
+		container.healthChecker.Register("mysql", health.DBCheck(db.DB))
 
 		// TODO: это конекшены к другим сервисам (в данном случае - gRPC)
 		testConnection, err := grpc.NewClient(
@@ -67,6 +83,7 @@ func newConnectionsContainer(
 
 		multiCloser.Add(testConnection)
 		container.testConnection = testConnection
+		container.healthChecker.Register("test", health.GRPCConnCheck(testConnection))
 
 		return nil
 	}
@@ -76,21 +93,26 @@ func newConnectionsContainer(
 
 type connectionsContainer struct {
 	db             *sqlx.DB
-	testConnection grpc.ClientConnInterface
+	testConnection *grpc.ClientConn
+	healthChecker  health.Checker
 }
 
-func initMySQL(cfg *config) (db *sqlx.DB, err error) {
-	db, err = sqlx.Connect("mysql", cfg.buildDSN())
-	if err != nil || db == nil {
-		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
+func initMySQL(cfg *config) (*sqlx.DB, error) {
+	db, err := sqlx.Open("mysql", cfg.buildDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open MySQL: %w", err)
 	}
 	db.SetMaxOpenConns(cfg.DBMaxConn)
-
-	defer func() {
-		if err != nil {
-			db.Close()
-		}
-	}()
+	db.SetMaxIdleConns(cfg.DBMaxIdleConn)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DBPingTimeout)
+	defer cancel()
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
+	}
 
 	return db, nil
 }
@@ -1,6 +1,10 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/health"
+)
 
 // TODO: добавить зависимости
 
@@ -9,10 +13,12 @@ func newDependencyContainer(
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
 	return &dependencyContainer{
-		db: connContainer.db,
+		db:            connContainer.db,
+		healthChecker: connContainer.healthChecker,
 	}, nil
 }
 
 type dependencyContainer struct {
-	db *sqlx.DB
+	db            *sqlx.DB
+	healthChecker health.Checker
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	api "github.com/GrigoriyPoshnagovInstitute/OrderService/api/server/orderinternal"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/health"
+)
+
+var errServiceUnhealthy = errors.New("service is unhealthy")
+
+func healthCheck(config *config) *cli.Command {
+	return &cli.Command{
+		Name:  "health",
+		Usage: "Checks health of the running service and its dependencies",
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithTimeout(c.Context, config.HealthCheckTimeout)
+			defer cancel()
+
+			conn, err := grpc.NewClient(
+				localGRPCTarget(config.ServeGRPCAddress),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			if err != nil {
+				return errors.Wrap(err, "failed to create gRPC client")
+			}
+			defer conn.Close()
+
+			resp, err := api.NewOrderInternalServiceClient(conn).Health(ctx, &api.HealthRequest{})
+			if err != nil {
+				return errors.Wrap(err, "health request failed")
+			}
+
+			for _, dependency := range resp.Dependencies {
+				line := fmt.Sprintf("%s: %s (%dms)", dependency.Name, dependency.Status, dependency.DurationMs)
+				if dependency.Error != "" {
+					line += ": " + dependency.Error
+				}
+				fmt.Fprintln(c.App.Writer, line)
+			}
+			fmt.Fprintf(c.App.Writer, "status: %s\n", resp.Status)
+
+			if resp.Status != string(health.StatusUp) {
+				return errServiceUnhealthy
+			}
+			return nil
+		},
+	}
+}
+
+func localGRPCTarget(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || host != "" {
+		return address
+	}
+	return net.JoinHostPort("localhost", port)
+}
@@ -58,6 +58,7 @@ func runApp(
 		Commands: []*cli.Command{
 			service(config, logger, closer),
 			migrate(config, logger),
+			healthCheck(config),
 		},
 	}
 
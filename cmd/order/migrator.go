@@ -29,6 +29,7 @@ func migrate(
 			if err != nil {
 				return err
 			}
+			defer db.Close()
 
 			if err := applyMigrations(db.DB, pathToMigrations); err != nil {
 				return fmt.Errorf("migration failed: %w", err)
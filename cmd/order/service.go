@@ -11,10 +11,12 @@ import (
 	"google.golang.org/grpc"
 
 	api "github.com/GrigoriyPoshnagovInstitute/OrderService/api/server/orderinternal"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/lifecycle"
 	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/transport"
 )
 
-const shutdownTimeout = 30 * time.Second
+// connectionsCloseTimeout is not taken from the shutdown timeout, connections must be closed even after it is exceeded
+const connectionsCloseTimeout = 5 * time.Second
 
 func service(
 	config *config,
@@ -34,7 +36,7 @@ func service(
 			if err != nil {
 				return errors.Wrap(err, "failed to init dependencies")
 			}
-			return startGRPCServer(c.Context, config, logger, container)
+			return startGRPCServer(c.Context, config, logger, container, closer)
 		},
 	}
 }
@@ -43,12 +45,13 @@ func startGRPCServer(
 	ctx context.Context,
 	config *config,
 	logger *log.Logger,
-	_ *dependencyContainer,
+	container *dependencyContainer,
+	closer *multiCloser,
 ) error {
 	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(makeGrpcUnaryInterceptor(logger)))
 
 	// TODO: зарегистрировать свой сервер вместо шаблонного
-	api.RegisterOrderInternalServiceServer(grpcServer, transport.NewInternalAPI())
+	api.RegisterOrderInternalServiceServer(grpcServer, transport.NewInternalAPI(container.healthChecker))
 
 	listener, err := net.Listen("tcp", config.ServeGRPCAddress)
 	if err != nil {
@@ -65,26 +68,23 @@ func startGRPCServer(
 	case err := <-errCh:
 		return err
 	case <-ctx.Done():
-		logger.Infof("Shutdown signal received, stopping gRPC server...")
-		shutdownGRPCServer(grpcServer, logger)
-		return nil
+		logger.Infof("Shutdown signal received, stopping service...")
+		return newShutdown(config, logger, grpcServer, closer).Run()
 	}
 }
 
-func shutdownGRPCServer(server *grpc.Server, logger *log.Logger) {
-	done := make(chan struct{})
-	go func() {
-		server.GracefulStop()
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		logger.Infof("gRPC server stopped gracefully")
-	case <-time.After(shutdownTimeout):
-		logger.Warnf("Graceful shutdown timed out after %v, forcing stop", shutdownTimeout)
-		server.Stop()
-	}
+// stop accepting requests first, then drain dispatchers and close connections,
+// so in-flight calls can still dispatch events and use repositories
+func newShutdown(
+	config *config,
+	logger *log.Logger,
+	grpcServer *grpc.Server,
+	closer *multiCloser,
+) lifecycle.Shutdown {
+	shutdown := lifecycle.NewShutdown(config.ShutdownTimeout, logger)
+	shutdown.Add("grpc server", lifecycle.Graceful(grpcServer.GracefulStop, grpcServer.Stop))
+	shutdown.AddWithTimeout("connections", connectionsCloseTimeout, lifecycle.Blocking(closer.Close))
+	return shutdown
 }
 
 func makeGrpcUnaryInterceptor(logger *log.Logger) grpc.UnaryServerInterceptor {
@@ -0,0 +1,9 @@
+package main
+
+import "github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+
+type discardDispatcher struct{}
+
+func (discardDispatcher) Dispatch(service.Event) error {
+	return nil
+}
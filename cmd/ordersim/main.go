@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	stdlog "log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/chaos"
+)
+
+// ordersim drives order service in process, the gRPC API has no order methods to drive yet
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := cli.App{
+		Name:  "ordersim",
+		Usage: "Run synthetic workload against order service and report throughput and latency",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{Name: "duration", Value: 10 * time.Second},
+			&cli.IntFlag{Name: "concurrency", Value: 8},
+			&cli.IntFlag{Name: "customers", Value: 1000},
+			&cli.Float64Flag{Name: "customer-skew", Usage: "zipf skew of customers, values above 1 favour few customers, 0 is uniform"},
+			&cli.StringFlag{Name: "mix", Value: "create=1,item=4,status=1", Usage: "weights of operations"},
+			&cli.DurationFlag{Name: "storage-latency", Usage: "latency added to every repository call"},
+		},
+		Action: func(c *cli.Context) error {
+			mix, err := parseMix(c.String("mix"))
+			if err != nil {
+				return err
+			}
+
+			memory := modeltest.NewFakeOrderRepository()
+			// recorded calls would grow for the whole run and show up in the measurements
+			memory.StopRecording()
+			repo := chaos.NewOrderRepository(memory, storageLatency(c.Duration("storage-latency"))...)
+			orderService := service.NewOrderService(repo, discardDispatcher{})
+
+			workload := workload{
+				service:     orderService,
+				mix:         mix,
+				customers:   c.Int("customers"),
+				skew:        c.Float64("customer-skew"),
+				concurrency: c.Int("concurrency"),
+			}
+			report, err := workload.run(c.Context, c.Duration("duration"))
+			if err != nil {
+				return err
+			}
+			return report.write(os.Stdout)
+		},
+	}
+
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		stdlog.Fatal(err)
+	}
+}
+
+func storageLatency(latency time.Duration) []chaos.Option {
+	if latency <= 0 {
+		return nil
+	}
+	fault := chaos.Fault{Latency: latency}
+	var opts []chaos.Option
+	for _, method := range []string{chaos.MethodNextID, chaos.MethodStore, chaos.MethodFind} {
+		opts = append(opts, chaos.WithFault(method, fault))
+	}
+	return opts
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type operationStats struct {
+	latencies []time.Duration
+	errors    int
+}
+
+type report struct {
+	mu         sync.Mutex
+	start      time.Time
+	elapsed    time.Duration
+	operations map[string]*operationStats
+}
+
+func newReport() *report {
+	return &report{
+		start:      time.Now(),
+		operations: make(map[string]*operationStats),
+	}
+}
+
+func (r *report) record(op string, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats, ok := r.operations[op]
+	if !ok {
+		stats = &operationStats{}
+		r.operations[op] = stats
+	}
+	stats.latencies = append(stats.latencies, latency)
+	if err != nil {
+		stats.errors++
+	}
+}
+
+func (r *report) finish() {
+	r.elapsed = time.Since(r.start)
+}
+
+func (r *report) write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "operation\tcount\terrors\tper second\tp50\tp95\tp99")
+
+	ops := make([]string, 0, len(r.operations))
+	for op := range r.operations {
+		ops = append(ops, op)
+	}
+	slices.Sort(ops)
+
+	for _, op := range ops {
+		stats := r.operations[op]
+		slices.Sort(stats.latencies)
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\n",
+			op,
+			len(stats.latencies),
+			stats.errors,
+			float64(len(stats.latencies))/r.elapsed.Seconds(),
+			percentile(stats.latencies, 0.50),
+			percentile(stats.latencies, 0.95),
+			percentile(stats.latencies, 0.99),
+		)
+	}
+	return tw.Flush()
+}
+
+// percentile expects sorted latencies
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	return latencies[int(float64(len(latencies)-1)*p)]
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+const (
+	opCreate = "create"
+	opItem   = "item"
+	opStatus = "status"
+)
+
+var errInvalidMix = errors.New("invalid operations mix")
+
+type mix map[string]int
+
+// parseMix parses weights like "create=1,item=4,status=1"
+func parseMix(s string) (mix, error) {
+	m := make(mix)
+	for _, part := range strings.Split(s, ",") {
+		op, weight, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, errInvalidMix
+		}
+		if op != opCreate && op != opItem && op != opStatus {
+			return nil, errInvalidMix
+		}
+		w, err := strconv.Atoi(weight)
+		if err != nil || w < 0 {
+			return nil, errInvalidMix
+		}
+		m[op] = w
+	}
+	if m[opCreate] == 0 {
+		// other operations need orders to work with
+		return nil, errInvalidMix
+	}
+	return m, nil
+}
+
+func (m mix) pick(random *rand.Rand) string {
+	total := m[opCreate] + m[opItem] + m[opStatus]
+	n := random.IntN(total)
+	for _, op := range []string{opCreate, opItem} {
+		if n < m[op] {
+			return op
+		}
+		n -= m[op]
+	}
+	return opStatus
+}
+
+type workload struct {
+	service     service.Order
+	mix         mix
+	customers   int
+	skew        float64
+	concurrency int
+}
+
+func (w workload) run(ctx context.Context, duration time.Duration) (*report, error) {
+	if w.customers <= 0 || w.concurrency <= 0 {
+		return nil, errors.New("customers and concurrency must be positive")
+	}
+	customerIDs := make([]uuid.UUID, w.customers)
+	for i := range customerIDs {
+		customerIDs[i] = uuid.New()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	r := newReport()
+	var wg sync.WaitGroup
+	for i := range w.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.worker(ctx, rand.New(rand.NewPCG(uint64(i), uint64(time.Now().UnixNano()))), customerIDs, r)
+		}()
+	}
+	wg.Wait()
+	r.finish()
+
+	return r, nil
+}
+
+// worker mutates only orders it created itself, so workers never wait on each other's orders.
+// Items are added to open orders only, an order moves to pending and then leaves the workload as paid or cancelled
+func (w workload) worker(ctx context.Context, random *rand.Rand, customerIDs []uuid.UUID, r *report) {
+	customer := w.customerPicker(random, len(customerIDs))
+	var open, pending []uuid.UUID
+
+	for ctx.Err() == nil {
+		op := w.mix.pick(random)
+		if len(open) == 0 && (op == opItem || len(pending) == 0) {
+			op = opCreate
+		}
+
+		start := time.Now()
+		var err error
+		switch op {
+		case opCreate:
+			var orderID uuid.UUID
+			orderID, err = w.service.CreateOrder(customerIDs[customer()])
+			if err == nil {
+				open = append(open, orderID)
+			}
+		case opItem:
+			_, err = w.service.AddItem(open[random.IntN(len(open))], uuid.New(), float64(random.IntN(10000))/100)
+		case opStatus:
+			i := random.IntN(len(open) + len(pending))
+			if i < len(open) {
+				orderID := open[i]
+				open = append(open[:i], open[i+1:]...)
+				if err = w.service.SetStatus(orderID, model.Pending); err == nil {
+					pending = append(pending, orderID)
+				}
+				break
+			}
+			i -= len(open)
+			status := model.Paid
+			if random.IntN(10) == 0 {
+				status = model.Cancelled
+			}
+			err = w.service.SetStatus(pending[i], status)
+			pending = append(pending[:i], pending[i+1:]...)
+		}
+		r.record(op, time.Since(start), err)
+	}
+}
+
+func (w workload) customerPicker(random *rand.Rand, customers int) func() int {
+	if w.skew <= 1 {
+		return func() int {
+			return random.IntN(customers)
+		}
+	}
+	zipf := rand.NewZipf(random, w.skew, 1, uint64(customers-1))
+	return func() int {
+		return int(zipf.Uint64())
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS customer_stats;
\ No newline at end of file
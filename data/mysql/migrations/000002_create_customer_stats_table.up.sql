@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS customer_stats
+(
+    `customer_id`   BINARY(16)     NOT NULL,
+    `order_count`   INT            NOT NULL DEFAULT 0,
+    `total_spend`   DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    `last_order_at` DATETIME       NULL,
+    PRIMARY KEY (`customer_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
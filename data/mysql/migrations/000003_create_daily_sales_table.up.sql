@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS daily_sales
+(
+    `day`     DATE           NOT NULL,
+    `status`  TINYINT        NOT NULL,
+    `orders`  INT            NOT NULL DEFAULT 0,
+    `revenue` DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    PRIMARY KEY (`day`, `status`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
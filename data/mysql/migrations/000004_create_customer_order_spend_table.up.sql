@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS customer_order_spend
+(
+    `order_id`    BINARY(16)     NOT NULL,
+    `customer_id` BINARY(16)     NOT NULL,
+    `amount`      DECIMAL(19, 4) NOT NULL,
+    PRIMARY KEY (`order_id`),
+    INDEX `customer_id_idx` (`customer_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
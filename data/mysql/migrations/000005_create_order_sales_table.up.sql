@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS order_sales
+(
+    `order_id` BINARY(16)     NOT NULL,
+    `day`      DATE           NOT NULL,
+    `status`   TINYINT        NOT NULL,
+    `revenue`  DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    PRIMARY KEY (`order_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
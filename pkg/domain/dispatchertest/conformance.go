@@ -0,0 +1,120 @@
+package dispatchertest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var errSink = errors.New("sink failed")
+
+type Subject struct {
+	Dispatcher service.EventDispatcher
+	// Flush waits until all dispatched events reach the sink, it may be nil for synchronous dispatchers
+	Flush func()
+	// Synchronous dispatchers deliver events before Dispatch returns and must return sink errors
+	Synchronous bool
+}
+
+// Factory creates dispatcher under test which delivers events to sink, e.g. through a broker and a consumer
+type Factory func(t *testing.T, sink service.EventDispatcher) Subject
+
+// RunConformanceTests verifies delivery and ordering guarantees of service.EventDispatcher implementations
+func RunConformanceTests(t *testing.T, factory Factory) {
+	t.Helper()
+
+	t.Run("should deliver every event once", func(t *testing.T) {
+		sink := modeltest.NewFakeEventDispatcher()
+		subject := factory(t, sink)
+
+		events := orderEvents(modeltest.NewOrderBuilder().Build())
+		for _, event := range events {
+			require.NoError(t, subject.Dispatcher.Dispatch(event))
+		}
+		flush(subject)
+
+		require.ElementsMatch(t, events, sink.Events())
+	})
+
+	t.Run("should keep order of events of one order", func(t *testing.T) {
+		sink := modeltest.NewFakeEventDispatcher()
+		subject := factory(t, sink)
+
+		first, second := modeltest.NewOrderBuilder().Build(), modeltest.NewOrderBuilder().Build()
+		firstEvents, secondEvents := orderEvents(first), orderEvents(second)
+		for i := range firstEvents {
+			require.NoError(t, subject.Dispatcher.Dispatch(firstEvents[i]))
+			require.NoError(t, subject.Dispatcher.Dispatch(secondEvents[i]))
+		}
+		flush(subject)
+
+		delivered := sink.Events()
+		require.Equal(t, firstEvents, eventsOf(delivered, first.ID))
+		require.Equal(t, secondEvents, eventsOf(delivered, second.ID))
+	})
+
+	t.Run("should deliver events dispatched concurrently", func(t *testing.T) {
+		sink := modeltest.NewFakeEventDispatcher()
+		subject := factory(t, sink)
+
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, event := range orderEvents(modeltest.NewOrderBuilder().Build()) {
+					require.NoError(t, subject.Dispatcher.Dispatch(event))
+				}
+			}()
+		}
+		wg.Wait()
+		flush(subject)
+
+		require.Len(t, sink.Events(), 20*4)
+	})
+
+	t.Run("should return sink errors from synchronous dispatch", func(t *testing.T) {
+		sink := modeltest.NewFakeEventDispatcher()
+		subject := factory(t, sink)
+		if !subject.Synchronous {
+			t.Skip("dispatcher is asynchronous")
+		}
+
+		sink.FailNext("Dispatch", errSink)
+		err := subject.Dispatcher.Dispatch(model.OrderDeleted{OrderID: uuid.Must(uuid.NewV7())})
+		require.ErrorIs(t, err, errSink)
+	})
+}
+
+func orderEvents(order *model.Order) []service.Event {
+	itemID := uuid.Must(uuid.NewV7())
+	return []service.Event{
+		modeltest.OrderCreated(order),
+		modeltest.ItemsAdded(order, itemID),
+		modeltest.StatusChanged(order, model.Paid),
+		modeltest.OrderDeleted(order),
+	}
+}
+
+func eventsOf(events []service.Event, orderID uuid.UUID) []service.Event {
+	var result []service.Event
+	for _, event := range events {
+		if e, ok := event.(interface{ AggregateID() uuid.UUID }); ok && e.AggregateID() == orderID {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func flush(subject Subject) {
+	if subject.Flush != nil {
+		subject.Flush()
+	}
+}
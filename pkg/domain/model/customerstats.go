@@ -0,0 +1,29 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrCustomerStatsNotFound = errors.New("customer stats not found")
+
+type CustomerStats struct {
+	CustomerID  uuid.UUID  `json:"customer_id"`
+	OrderCount  int        `json:"order_count"`
+	TotalSpend  float64    `json:"total_spend"`
+	LastOrderAt *time.Time `json:"last_order_at,omitempty"`
+}
+
+type CustomerStatsRepository interface {
+	// RecordOrder increments order count of the customer atomically
+	RecordOrder(customerID uuid.UUID, orderedAt time.Time) error
+	// SetOrderSpend replaces spend of the order counted in total spend of the customer atomically,
+	// zero amount removes the order from total spend
+	SetOrderSpend(customerID, orderID uuid.UUID, amount float64) error
+	Find(customerID uuid.UUID) (*CustomerStats, error)
+	// Relink merges stats and per-order spend of the customer into the ones of pseudonym atomically,
+	// relinking missing stats is not an error
+	Relink(customerID, pseudonym uuid.UUID) error
+}
@@ -3,37 +3,89 @@ package model
 import "github.com/google/uuid"
 
 type OrderCreated struct {
-	OrderID    uuid.UUID
-	CustomerID uuid.UUID
+	OrderID    uuid.UUID `json:"order_id"`
+	CustomerID uuid.UUID `json:"customer_id"`
 }
 
 func (e OrderCreated) Type() string {
 	return "OrderCreated"
 }
 
+func (e OrderCreated) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
 type OrderItemsChanged struct {
-	OrderID      uuid.UUID
-	AddedItems   []uuid.UUID
-	RemovedItems []uuid.UUID
+	OrderID      uuid.UUID   `json:"order_id"`
+	AddedItems   []uuid.UUID `json:"added_items,omitempty"`
+	RemovedItems []uuid.UUID `json:"removed_items,omitempty"`
 }
 
 func (e OrderItemsChanged) Type() string {
 	return "OrderItemsChanged"
 }
 
+func (e OrderItemsChanged) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
+type OrderTagsChanged struct {
+	OrderID     uuid.UUID `json:"order_id"`
+	AddedTags   []string  `json:"added_tags,omitempty"`
+	RemovedTags []string  `json:"removed_tags,omitempty"`
+}
+
+func (e OrderTagsChanged) Type() string {
+	return "OrderTagsChanged"
+}
+
+func (e OrderTagsChanged) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
 type OrderStatusChanged struct {
-	OrderID   uuid.UUID
-	NewStatus OrderStatus
+	OrderID   uuid.UUID   `json:"order_id"`
+	NewStatus OrderStatus `json:"new_status"`
 }
 
 func (e OrderStatusChanged) Type() string {
 	return "OrderStatusChanged"
 }
 
+func (e OrderStatusChanged) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
+type OrderStatusBulkChanged struct {
+	OrderIDs  []uuid.UUID `json:"order_ids"`
+	NewStatus OrderStatus `json:"new_status"`
+}
+
+func (e OrderStatusBulkChanged) Type() string {
+	return "OrderStatusBulkChanged"
+}
+
 type OrderDeleted struct {
-	OrderID uuid.UUID
+	OrderID uuid.UUID `json:"order_id"`
 }
 
 func (e OrderDeleted) Type() string {
 	return "OrderDeleted"
 }
+
+func (e OrderDeleted) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
+// OrderAnonymized does not carry customer ID, the order is linked to a random customer ID instead
+type OrderAnonymized struct {
+	OrderID uuid.UUID `json:"order_id"`
+}
+
+func (e OrderAnonymized) Type() string {
+	return "OrderAnonymized"
+}
+
+func (e OrderAnonymized) AggregateID() uuid.UUID {
+	return e.OrderID
+}
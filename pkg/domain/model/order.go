@@ -2,12 +2,20 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-var ErrOrderNotFound = errors.New("order not found")
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrUnknownOrderStatus = errors.New("unknown order status")
+
+	ErrOpenOrdersLimitExceeded = errors.New("open orders limit exceeded")
+)
 
 type OrderStatus int
 
@@ -18,25 +26,143 @@ const (
 	Cancelled
 )
 
+// status names are part of API payloads and stored events, they must not be renamed
+var orderStatusNames = map[OrderStatus]string{
+	Open:      "open",
+	Pending:   "pending",
+	Paid:      "paid",
+	Cancelled: "cancelled",
+}
+
+func (s OrderStatus) String() string {
+	if name, ok := orderStatusNames[s]; ok {
+		return name
+	}
+	return "unknown(" + strconv.Itoa(int(s)) + ")"
+}
+
+func (s OrderStatus) MarshalText() ([]byte, error) {
+	name, ok := orderStatusNames[s]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownOrderStatus, int(s))
+	}
+	return []byte(name), nil
+}
+
+func (s *OrderStatus) UnmarshalText(text []byte) error {
+	for status, name := range orderStatusNames {
+		if name == string(text) {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownOrderStatus, text)
+}
+
+// IsTerminal reports whether order in this status is not expected to change anymore
+func (s OrderStatus) IsTerminal() bool {
+	return s == Paid || s == Cancelled
+}
+
 type Order struct {
-	ID         uuid.UUID
-	CustomerID uuid.UUID
-	Status     OrderStatus
-	Items      []Item
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	DeletedAt  *time.Time
+	ID         uuid.UUID   `json:"id"`
+	CustomerID uuid.UUID   `json:"customer_id"`
+	Status     OrderStatus `json:"status"`
+	Items      []Item      `json:"items"`
+	Tags       []string    `json:"tags,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	DeletedAt  *time.Time  `json:"deleted_at,omitempty"`
+}
+
+// HasTag expects normalized tag, order tags are kept normalized and sorted
+func (o *Order) HasTag(tag string) bool {
+	_, found := slices.BinarySearch(o.Tags, tag)
+	return found
 }
 
 type Item struct {
-	ID        uuid.UUID
-	ProductID uuid.UUID
-	Price     float64
+	ID          uuid.UUID         `json:"id"`
+	ProductID   uuid.UUID         `json:"product_id"`
+	Price       float64           `json:"price"`
+	Adjustments []PriceAdjustment `json:"adjustments,omitempty"`
+}
+
+// PriceAdjustment explains how pricing changed item price, Amount is negative for discounts
+type PriceAdjustment struct {
+	Reason string  `json:"reason"`
+	Amount float64 `json:"amount"`
+}
+
+// OrderFilter selects orders, zero fields match any order
+type OrderFilter struct {
+	CustomerID    uuid.UUID
+	Statuses      []OrderStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedBefore time.Time
+	// Tags match orders having all of them, AnyTags match orders having at least one of them.
+	// Tags are normalized before matching, invalid tags are never present on orders
+	Tags    []string
+	AnyTags []string
+}
+
+func (f OrderFilter) Matches(order *Order) bool {
+	if f.CustomerID != uuid.Nil && order.CustomerID != f.CustomerID {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, order.Status) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && !order.CreatedAt.After(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !order.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	if !f.UpdatedBefore.IsZero() && !order.UpdatedAt.Before(f.UpdatedBefore) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !hasFilterTag(order, tag) {
+			return false
+		}
+	}
+	if len(f.AnyTags) > 0 && !slices.ContainsFunc(f.AnyTags, func(tag string) bool { return hasFilterTag(order, tag) }) {
+		return false
+	}
+	return true
+}
+
+func hasFilterTag(order *Order, tag string) bool {
+	normalized, err := NormalizeTag(tag)
+	return err == nil && order.HasTag(normalized)
 }
 
 type OrderRepository interface {
 	NextID() (uuid.UUID, error)
 	Store(order *Order) error
+	StoreMany(orders []*Order) error
 	Find(id uuid.UUID) (*Order, error)
+	// FindMany returns found orders by their IDs, missing and deleted orders are absent in the result
+	FindMany(ids []uuid.UUID) (map[uuid.UUID]*Order, error)
+	// StreamOrders calls fn for every not deleted order matching filter without loading all of them at once,
+	// streaming stops on the first error returned by fn
+	StreamOrders(filter OrderFilter, fn func(order *Order) error) error
 	Delete(id uuid.UUID) error
+	// Purge removes order permanently, unlike Delete which only marks it as deleted
+	Purge(id uuid.UUID) error
+}
+
+// ArchiveRepository keeps terminal orders moved out of the OrderRepository
+type ArchiveRepository interface {
+	Store(order *Order) error
+	Find(id uuid.UUID) (*Order, error)
+	Delete(id uuid.UUID) error
+}
+
+// OpenOrdersLimitedStore is implemented by repositories able to count open orders and store in one atomic step
+type OpenOrdersLimitedStore interface {
+	// StoreWithOpenOrdersLimit stores new order unless its customer already has limit not deleted open orders
+	StoreWithOpenOrdersLimit(order *Order, limit int) error
 }
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SalesRow aggregates orders which are in Status now and entered it during the period starting at Period,
+// Revenue is counted only for Paid
+type SalesRow struct {
+	Period  time.Time   `json:"period"`
+	Status  OrderStatus `json:"status"`
+	Orders  int         `json:"orders"`
+	Revenue float64     `json:"revenue"`
+}
+
+type SalesReportRepository interface {
+	// Record moves order to the daily aggregate of day and status atomically,
+	// the aggregate the order was recorded in before no longer counts it
+	Record(orderID uuid.UUID, day time.Time, status OrderStatus, revenue float64) error
+	// Remove stops counting the order, it is a no-op for orders which were never recorded
+	Remove(orderID uuid.UUID) error
+	// FindDaily returns daily rows of days in [from, to) ordered by day and status
+	FindDaily(from, to time.Time) ([]SalesRow, error)
+}
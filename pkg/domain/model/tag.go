@@ -0,0 +1,29 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+const MaxTagLength = 64
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// NormalizeTag lowercases tag and joins its words with "-", so "Manual Review" and "manual-review" are one tag.
+// Only letters, digits, "-", "_" and ":" are allowed
+func NormalizeTag(tag string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+	if normalized == "" || len(normalized) > MaxTagLength {
+		return "", ErrInvalidTag
+	}
+	for _, r := range normalized {
+		if !isTagRune(r) {
+			return "", ErrInvalidTag
+		}
+	}
+	return normalized, nil
+}
+
+func isTagRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == ':'
+}
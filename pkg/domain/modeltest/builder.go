@@ -0,0 +1,90 @@
+package modeltest
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+const DefaultItemPrice = 100
+
+// OrderBuilder builds valid orders for tests, by default an empty Open order of a random customer
+type OrderBuilder struct {
+	order model.Order
+}
+
+func NewOrderBuilder() *OrderBuilder {
+	now := time.Now().UTC()
+	return &OrderBuilder{
+		order: model.Order{
+			ID:         uuid.Must(uuid.NewV7()),
+			CustomerID: uuid.Must(uuid.NewV7()),
+			Status:     model.Open,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		},
+	}
+}
+
+func (b *OrderBuilder) WithID(id uuid.UUID) *OrderBuilder {
+	b.order.ID = id
+	return b
+}
+
+func (b *OrderBuilder) WithCustomerID(customerID uuid.UUID) *OrderBuilder {
+	b.order.CustomerID = customerID
+	return b
+}
+
+func (b *OrderBuilder) WithStatus(status model.OrderStatus) *OrderBuilder {
+	b.order.Status = status
+	return b
+}
+
+// WithItems adds count items of random products with DefaultItemPrice
+func (b *OrderBuilder) WithItems(count int) *OrderBuilder {
+	for range count {
+		b.WithItem(uuid.Must(uuid.NewV7()), DefaultItemPrice)
+	}
+	return b
+}
+
+func (b *OrderBuilder) WithItem(productID uuid.UUID, price float64) *OrderBuilder {
+	b.order.Items = append(b.order.Items, model.Item{
+		ID:        uuid.Must(uuid.NewV7()),
+		ProductID: productID,
+		Price:     price,
+	})
+	return b
+}
+
+// WithTags expects normalized tags and keeps them sorted
+func (b *OrderBuilder) WithTags(tags ...string) *OrderBuilder {
+	b.order.Tags = append(b.order.Tags, tags...)
+	slices.Sort(b.order.Tags)
+	return b
+}
+
+func (b *OrderBuilder) WithCreatedAt(createdAt time.Time) *OrderBuilder {
+	b.order.CreatedAt = createdAt
+	return b
+}
+
+func (b *OrderBuilder) WithUpdatedAt(updatedAt time.Time) *OrderBuilder {
+	b.order.UpdatedAt = updatedAt
+	return b
+}
+
+func (b *OrderBuilder) Deleted() *OrderBuilder {
+	deletedAt := b.order.UpdatedAt
+	b.order.DeletedAt = &deletedAt
+	return b
+}
+
+// Build returns a copy, so the builder can be reused for similar orders
+func (b *OrderBuilder) Build() *model.Order {
+	return copyOrder(&b.order)
+}
@@ -0,0 +1,75 @@
+package modeltest
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var (
+	_ service.Clock       = &FakeClock{}
+	_ service.IDGenerator = &SequentialIDGenerator{}
+)
+
+// FakeClock stands still until it is advanced manually
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// SequentialIDGenerator returns valid UUIDv7 with timestamp of start and a counter instead of random bits,
+// so generators created with the same start return the same increasing IDs
+type SequentialIDGenerator struct {
+	mu      sync.Mutex
+	start   time.Time
+	counter uint64
+}
+
+func NewSequentialIDGenerator(start time.Time) *SequentialIDGenerator {
+	return &SequentialIDGenerator{start: start}
+}
+
+func (g *SequentialIDGenerator) NextID() (uuid.UUID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.counter++
+
+	var id uuid.UUID
+	ms := uint64(g.start.UnixMilli())
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+	id[6] = 0x70
+	binary.BigEndian.PutUint64(id[8:], g.counter)
+	id[8] = id[8]&0x3f | 0x80
+	return id, nil
+}
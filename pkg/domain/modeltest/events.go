@@ -0,0 +1,41 @@
+package modeltest
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+func OrderCreated(order *model.Order) model.OrderCreated {
+	return model.OrderCreated{
+		OrderID:    order.ID,
+		CustomerID: order.CustomerID,
+	}
+}
+
+func ItemsAdded(order *model.Order, itemIDs ...uuid.UUID) model.OrderItemsChanged {
+	return model.OrderItemsChanged{
+		OrderID:    order.ID,
+		AddedItems: itemIDs,
+	}
+}
+
+func ItemsRemoved(order *model.Order, itemIDs ...uuid.UUID) model.OrderItemsChanged {
+	return model.OrderItemsChanged{
+		OrderID:      order.ID,
+		RemovedItems: itemIDs,
+	}
+}
+
+func StatusChanged(order *model.Order, status model.OrderStatus) model.OrderStatusChanged {
+	return model.OrderStatusChanged{
+		OrderID:   order.ID,
+		NewStatus: status,
+	}
+}
+
+func OrderDeleted(order *model.Order) model.OrderDeleted {
+	return model.OrderDeleted{
+		OrderID: order.ID,
+	}
+}
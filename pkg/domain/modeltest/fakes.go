@@ -0,0 +1,325 @@
+package modeltest
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var (
+	_ model.OrderRepository        = &FakeOrderRepository{}
+	_ model.OpenOrdersLimitedStore = &FakeOrderRepository{}
+	_ service.StuckOrderFinder     = &FakeOrderRepository{}
+	_ service.EventDispatcher      = &FakeEventDispatcher{}
+)
+
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// calls records calls and injects failures, it is shared by all fakes
+type calls struct {
+	mu       sync.Mutex
+	recorded []Call
+	failOn   map[string]error
+	failNext map[string]error
+	stopped  bool
+}
+
+func newCalls() calls {
+	return calls{
+		failOn:   make(map[string]error),
+		failNext: make(map[string]error),
+	}
+}
+
+// FailOn makes every call of method fail with err until Reset
+func (c *calls) FailOn(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failOn[method] = err
+}
+
+// FailNext makes only the next call of method fail with err
+func (c *calls) FailNext(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failNext[method] = err
+}
+
+func (c *calls) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.recorded)
+}
+
+func (c *calls) CallCount(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count := 0
+	for _, call := range c.recorded {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// StopRecording stops keeping calls, e.g. for long runs which would grow them without bound,
+// injected failures keep working
+func (c *calls) StopRecording() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recorded = nil
+	c.stopped = true
+}
+
+// Reset forgets recorded calls and injected failures
+func (c *calls) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recorded = nil
+	clear(c.failOn)
+	clear(c.failNext)
+}
+
+func (c *calls) record(method string, args ...any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.stopped {
+		c.recorded = append(c.recorded, Call{Method: method, Args: args})
+	}
+
+	if err, ok := c.failNext[method]; ok {
+		delete(c.failNext, method)
+		return err
+	}
+	return c.failOn[method]
+}
+
+// FakeOrderRepository is a thread-safe in-memory OrderRepository, orders are copied on the way in and out
+type FakeOrderRepository struct {
+	calls
+	mu    sync.RWMutex
+	store map[uuid.UUID]*model.Order
+}
+
+func NewFakeOrderRepository() *FakeOrderRepository {
+	return &FakeOrderRepository{
+		calls: newCalls(),
+		store: make(map[uuid.UUID]*model.Order),
+	}
+}
+
+// Get returns stored order including deleted ones without recording a call
+func (r *FakeOrderRepository) Get(id uuid.UUID) (*model.Order, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	order, ok := r.store[id]
+	if !ok {
+		return nil, false
+	}
+	return copyOrder(order), true
+}
+
+func (r *FakeOrderRepository) NextID() (uuid.UUID, error) {
+	if err := r.record("NextID"); err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.NewV7()
+}
+
+func (r *FakeOrderRepository) Store(order *model.Order) error {
+	if err := r.record("Store", order); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.store[order.ID] = copyOrder(order)
+	return nil
+}
+
+// StoreWithOpenOrdersLimit counts and stores under one lock like a transactional adapter would
+func (r *FakeOrderRepository) StoreWithOpenOrdersLimit(order *model.Order, limit int) error {
+	if err := r.record("StoreWithOpenOrdersLimit", order, limit); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	open := 0
+	for _, stored := range r.store {
+		if stored.CustomerID == order.CustomerID && stored.Status == model.Open && stored.DeletedAt == nil {
+			open++
+		}
+	}
+	if open >= limit {
+		return model.ErrOpenOrdersLimitExceeded
+	}
+	r.store[order.ID] = copyOrder(order)
+	return nil
+}
+
+func (r *FakeOrderRepository) StoreMany(orders []*model.Order) error {
+	if err := r.record("StoreMany", orders); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, order := range orders {
+		r.store[order.ID] = copyOrder(order)
+	}
+	return nil
+}
+
+func (r *FakeOrderRepository) Find(id uuid.UUID) (*model.Order, error) {
+	if err := r.record("Find", id); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	order, ok := r.store[id]
+	if !ok || order.DeletedAt != nil {
+		return nil, model.ErrOrderNotFound
+	}
+	return copyOrder(order), nil
+}
+
+func (r *FakeOrderRepository) FindMany(ids []uuid.UUID) (map[uuid.UUID]*model.Order, error) {
+	if err := r.record("FindMany", ids); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	orders := make(map[uuid.UUID]*model.Order, len(ids))
+	for _, id := range ids {
+		if order, ok := r.store[id]; ok && order.DeletedAt == nil {
+			orders[id] = copyOrder(order)
+		}
+	}
+	return orders, nil
+}
+
+// StreamOrders streams orders ordered by ID, so results are deterministic
+func (r *FakeOrderRepository) StreamOrders(filter model.OrderFilter, fn func(order *model.Order) error) error {
+	if err := r.record("StreamOrders", filter); err != nil {
+		return err
+	}
+	r.mu.RLock()
+	var orders []*model.Order
+	for _, order := range r.store {
+		if order.DeletedAt == nil && filter.Matches(order) {
+			orders = append(orders, copyOrder(order))
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(orders, func(a, b *model.Order) int {
+		return slices.Compare(a.ID[:], b.ID[:])
+	})
+	for _, order := range orders {
+		if err := fn(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *FakeOrderRepository) FindStuck(status model.OrderStatus, updatedBefore time.Time) ([]uuid.UUID, error) {
+	if err := r.record("FindStuck", status, updatedBefore); err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	err := r.StreamOrders(model.OrderFilter{
+		Statuses:      []model.OrderStatus{status},
+		UpdatedBefore: updatedBefore,
+	}, func(order *model.Order) error {
+		ids = append(ids, order.ID)
+		return nil
+	})
+	return ids, err
+}
+
+func (r *FakeOrderRepository) Delete(id uuid.UUID) error {
+	if err := r.record("Delete", id); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.store[id]
+	if !ok || order.DeletedAt != nil {
+		return model.ErrOrderNotFound
+	}
+	now := time.Now().UTC()
+	order.DeletedAt = &now
+	return nil
+}
+
+func (r *FakeOrderRepository) Purge(id uuid.UUID) error {
+	if err := r.record("Purge", id); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.store[id]; !ok {
+		return model.ErrOrderNotFound
+	}
+	delete(r.store, id)
+	return nil
+}
+
+// FakeEventDispatcher is a thread-safe EventDispatcher keeping dispatched events in order
+type FakeEventDispatcher struct {
+	calls
+	mu     sync.Mutex
+	events []service.Event
+}
+
+func NewFakeEventDispatcher() *FakeEventDispatcher {
+	return &FakeEventDispatcher{
+		calls: newCalls(),
+	}
+}
+
+// Dispatch records failed events too, but only successful ones are returned by Events
+func (d *FakeEventDispatcher) Dispatch(event service.Event) error {
+	if err := d.record("Dispatch", event); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, event)
+	return nil
+}
+
+func (d *FakeEventDispatcher) Events() []service.Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.events)
+}
+
+func (d *FakeEventDispatcher) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = nil
+}
+
+func copyOrder(order *model.Order) *model.Order {
+	orderCopy := *order
+	orderCopy.Items = slices.Clone(order.Items)
+	for i := range orderCopy.Items {
+		orderCopy.Items[i].Adjustments = slices.Clone(order.Items[i].Adjustments)
+	}
+	orderCopy.Tags = slices.Clone(order.Tags)
+	if order.DeletedAt != nil {
+		deletedAt := *order.DeletedAt
+		orderCopy.DeletedAt = &deletedAt
+	}
+	return &orderCopy
+}
@@ -0,0 +1,216 @@
+package repositorytest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+)
+
+var errStop = errors.New("stop streaming")
+
+// Factory returns an empty repository for every subtest
+type Factory func(t *testing.T) model.OrderRepository
+
+// RunConformanceTests verifies that repository implementation follows the model.OrderRepository contract
+func RunConformanceTests(t *testing.T, factory Factory) {
+	t.Helper()
+
+	t.Run("NextID should return unique IDs", func(t *testing.T) {
+		repo := factory(t)
+		ids := make(map[uuid.UUID]struct{})
+		for range 1000 {
+			id, err := repo.NextID()
+			require.NoError(t, err)
+			require.NotEqual(t, uuid.Nil, id)
+			require.NotContains(t, ids, id)
+			ids[id] = struct{}{}
+		}
+	})
+
+	t.Run("Find should return stored order", func(t *testing.T) {
+		repo := factory(t)
+		order := modeltest.NewOrderBuilder().WithStatus(model.Pending).WithItems(3).Build()
+		require.NoError(t, repo.Store(order))
+
+		found, err := repo.Find(order.ID)
+		require.NoError(t, err)
+		requireOrderEqual(t, order, found)
+	})
+
+	t.Run("Store should overwrite existing order", func(t *testing.T) {
+		repo := factory(t)
+		order := modeltest.NewOrderBuilder().WithItems(2).Build()
+		require.NoError(t, repo.Store(order))
+
+		order.Status = model.Paid
+		order.Items = order.Items[:1]
+		require.NoError(t, repo.Store(order))
+
+		found, err := repo.Find(order.ID)
+		require.NoError(t, err)
+		requireOrderEqual(t, order, found)
+	})
+
+	t.Run("Find should return ErrOrderNotFound for unknown order", func(t *testing.T) {
+		repo := factory(t)
+		_, err := repo.Find(uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+	})
+
+	t.Run("Delete should hide order", func(t *testing.T) {
+		repo := factory(t)
+		order := modeltest.NewOrderBuilder().Build()
+		require.NoError(t, repo.Store(order))
+
+		require.NoError(t, repo.Delete(order.ID))
+
+		_, err := repo.Find(order.ID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		orders, err := repo.FindMany([]uuid.UUID{order.ID})
+		require.NoError(t, err)
+		require.Empty(t, orders)
+		require.ErrorIs(t, repo.Delete(order.ID), model.ErrOrderNotFound)
+	})
+
+	t.Run("Delete should return ErrOrderNotFound for unknown order", func(t *testing.T) {
+		repo := factory(t)
+		require.ErrorIs(t, repo.Delete(uuid.Must(uuid.NewV7())), model.ErrOrderNotFound)
+	})
+
+	t.Run("Purge should remove order", func(t *testing.T) {
+		repo := factory(t)
+		order := modeltest.NewOrderBuilder().Build()
+		require.NoError(t, repo.Store(order))
+
+		require.NoError(t, repo.Purge(order.ID))
+
+		_, err := repo.Find(order.ID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		require.ErrorIs(t, repo.Purge(order.ID), model.ErrOrderNotFound)
+	})
+
+	t.Run("StoreMany and FindMany should handle batches", func(t *testing.T) {
+		repo := factory(t)
+		orders := []*model.Order{
+			modeltest.NewOrderBuilder().WithItems(1).Build(),
+			modeltest.NewOrderBuilder().WithStatus(model.Paid).Build(),
+		}
+		require.NoError(t, repo.StoreMany(orders))
+
+		missingID := uuid.Must(uuid.NewV7())
+		found, err := repo.FindMany([]uuid.UUID{orders[0].ID, orders[1].ID, missingID})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		requireOrderEqual(t, orders[0], found[orders[0].ID])
+		requireOrderEqual(t, orders[1], found[orders[1].ID])
+	})
+
+	t.Run("StreamOrders should stream orders matching filter", func(t *testing.T) {
+		repo := factory(t)
+		customerID := uuid.Must(uuid.NewV7())
+		matching := modeltest.NewOrderBuilder().WithCustomerID(customerID).WithStatus(model.Paid).Build()
+		deleted := modeltest.NewOrderBuilder().WithCustomerID(customerID).WithStatus(model.Paid).Build()
+		require.NoError(t, repo.StoreMany([]*model.Order{
+			matching,
+			deleted,
+			modeltest.NewOrderBuilder().WithCustomerID(customerID).Build(),
+			modeltest.NewOrderBuilder().WithStatus(model.Paid).Build(),
+		}))
+		require.NoError(t, repo.Delete(deleted.ID))
+
+		var streamed []uuid.UUID
+		err := repo.StreamOrders(model.OrderFilter{
+			CustomerID: customerID,
+			Statuses:   []model.OrderStatus{model.Paid},
+		}, func(order *model.Order) error {
+			streamed = append(streamed, order.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{matching.ID}, streamed)
+	})
+
+	t.Run("StreamOrders should filter by tag combinations", func(t *testing.T) {
+		repo := factory(t)
+		both := modeltest.NewOrderBuilder().WithTags("fraud", "manual-review").Build()
+		review := modeltest.NewOrderBuilder().WithTags("manual-review").Build()
+		require.NoError(t, repo.StoreMany([]*model.Order{
+			both,
+			review,
+			modeltest.NewOrderBuilder().WithTags("vip").Build(),
+			modeltest.NewOrderBuilder().Build(),
+		}))
+
+		stream := func(filter model.OrderFilter) []uuid.UUID {
+			var streamed []uuid.UUID
+			require.NoError(t, repo.StreamOrders(filter, func(order *model.Order) error {
+				streamed = append(streamed, order.ID)
+				return nil
+			}))
+			return streamed
+		}
+		require.ElementsMatch(t, []uuid.UUID{both.ID}, stream(model.OrderFilter{Tags: []string{"manual-review", "fraud"}}))
+		require.ElementsMatch(t, []uuid.UUID{both.ID, review.ID}, stream(model.OrderFilter{AnyTags: []string{"manual-review", "missing"}}))
+		require.ElementsMatch(t, []uuid.UUID{both.ID}, stream(model.OrderFilter{Tags: []string{"manual-review"}, AnyTags: []string{"fraud", "vip"}}))
+	})
+
+	t.Run("StreamOrders should stop on callback error", func(t *testing.T) {
+		repo := factory(t)
+		require.NoError(t, repo.StoreMany([]*model.Order{
+			modeltest.NewOrderBuilder().Build(),
+			modeltest.NewOrderBuilder().Build(),
+		}))
+
+		calls := 0
+		err := repo.StreamOrders(model.OrderFilter{}, func(*model.Order) error {
+			calls++
+			return errStop
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("StoreWithOpenOrdersLimit should count only open orders of the customer", func(t *testing.T) {
+		repo := factory(t)
+		store, ok := repo.(model.OpenOrdersLimitedStore)
+		if !ok {
+			t.Skip("repository does not implement model.OpenOrdersLimitedStore")
+		}
+		customerID := uuid.Must(uuid.NewV7())
+		require.NoError(t, repo.StoreMany([]*model.Order{
+			modeltest.NewOrderBuilder().WithCustomerID(customerID).Build(),
+			modeltest.NewOrderBuilder().WithCustomerID(customerID).WithStatus(model.Paid).Build(),
+			modeltest.NewOrderBuilder().WithCustomerID(customerID).Deleted().Build(),
+			modeltest.NewOrderBuilder().Build(),
+		}))
+
+		order := modeltest.NewOrderBuilder().WithCustomerID(customerID).Build()
+		require.NoError(t, store.StoreWithOpenOrdersLimit(order, 2))
+		stored, err := repo.Find(order.ID)
+		require.NoError(t, err)
+		requireOrderEqual(t, order, stored)
+
+		rejected := modeltest.NewOrderBuilder().WithCustomerID(customerID).Build()
+		require.ErrorIs(t, store.StoreWithOpenOrdersLimit(rejected, 2), model.ErrOpenOrdersLimitExceeded)
+		_, err = repo.Find(rejected.ID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+	})
+}
+
+// requireOrderEqual compares orders allowing storage to round timestamps to microseconds
+func requireOrderEqual(t *testing.T, expected, actual *model.Order) {
+	t.Helper()
+	require.NotNil(t, actual)
+	require.Equal(t, expected.ID, actual.ID)
+	require.Equal(t, expected.CustomerID, actual.CustomerID)
+	require.Equal(t, expected.Status, actual.Status)
+	require.ElementsMatch(t, expected.Items, actual.Items)
+	require.WithinDuration(t, expected.CreatedAt, actual.CreatedAt, time.Microsecond)
+	require.WithinDuration(t, expected.UpdatedAt, actual.UpdatedAt, time.Microsecond)
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+var (
+	ErrInvalidCustomerID = errors.New("invalid customer ID")
+	// ErrAnonymizedEventsNotDispatched means orders are relinked and audited, but some OrderAnonymized events were lost
+	ErrAnonymizedEventsNotDispatched = errors.New("anonymized orders events not dispatched")
+)
+
+// ErasureRecord proves that erasure was done without keeping erased customer ID
+type ErasureRecord struct {
+	OrderIDs []uuid.UUID
+	ErasedAt time.Time
+}
+
+type ErasureAuditLog interface {
+	Record(record ErasureRecord) error
+}
+
+// CustomerRelinker moves data kept by customer ID outside of orders, e.g. projections and trackers, to the pseudonym,
+// so aggregates survive erasure without the erased customer ID
+type CustomerRelinker interface {
+	// RelinkCustomer merges data of the customer into data of the pseudonym, it must succeed when there is
+	// nothing to relink, so erasure can be retried
+	RelinkCustomer(customerID, pseudonym uuid.UUID) error
+}
+
+type CustomerAnonymizer interface {
+	// AnonymizeCustomer relinks all orders of the customer to a random customer ID keeping items and prices,
+	// so financial aggregates stay correct, drops their free-form tags, relinks customer data of relinkers
+	// and returns number of anonymized orders.
+	// Orders are locked and read again batch by batch, so concurrent mutations are not overwritten, and audited
+	// before they are relinked, so a failed call can be retried and only orders still linked to the customer
+	// are anonymized again.
+	// Soft deleted and archived orders are not visible to it and must be purged separately
+	AnonymizeCustomer(customerID uuid.UUID) (int, error)
+}
+
+func NewCustomerAnonymizer(
+	repo model.OrderRepository,
+	dispatcher EventDispatcher,
+	locker DistributedLocker,
+	audit ErasureAuditLog,
+	clock Clock,
+	relinkers ...CustomerRelinker,
+) CustomerAnonymizer {
+	return &customerAnonymizer{
+		repo:       repo,
+		dispatcher: dispatcher,
+		locker:     locker,
+		audit:      audit,
+		clock:      clock,
+		relinkers:  relinkers,
+	}
+}
+
+type customerAnonymizer struct {
+	repo       model.OrderRepository
+	dispatcher EventDispatcher
+	locker     DistributedLocker
+	audit      ErasureAuditLog
+	clock      Clock
+	relinkers  []CustomerRelinker
+}
+
+func (a *customerAnonymizer) AnonymizeCustomer(customerID uuid.UUID) (int, error) {
+	if customerID == uuid.Nil {
+		return 0, ErrInvalidCustomerID
+	}
+
+	// IDs are collected first, writing while streaming could break cursors of storage adapters
+	var orderIDs []uuid.UUID
+	err := a.repo.StreamOrders(model.OrderFilter{CustomerID: customerID}, func(order *model.Order) error {
+		orderIDs = append(orderIDs, order.ID)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// random UUID is used on purpose, UUIDv7 would leak time of erasure request
+	pseudonym, err := uuid.NewRandom()
+	if err != nil {
+		return 0, err
+	}
+
+	anonymized := 0
+	var dispatchErrs []error
+	for start := 0; start < len(orderIDs); start += bulkBatchSize {
+		batchIDs := orderIDs[start:min(start+bulkBatchSize, len(orderIDs))]
+		batchAnonymized, batchErrs, err := a.anonymizeBatch(batchIDs, customerID, pseudonym)
+		anonymized += batchAnonymized
+		if err != nil {
+			return anonymized, err
+		}
+		dispatchErrs = append(dispatchErrs, batchErrs...)
+	}
+
+	for _, relinker := range a.relinkers {
+		if err = relinker.RelinkCustomer(customerID, pseudonym); err != nil {
+			return anonymized, err
+		}
+	}
+
+	if len(dispatchErrs) > 0 {
+		return anonymized, fmt.Errorf("%w: %w", ErrAnonymizedEventsNotDispatched, errors.Join(dispatchErrs...))
+	}
+	return anonymized, nil
+}
+
+// anonymizeBatch returns dispatch errors separately, the batch is relinked and audited when they happen
+func (a *customerAnonymizer) anonymizeBatch(
+	orderIDs []uuid.UUID,
+	customerID, pseudonym uuid.UUID,
+) (anonymized int, dispatchErrs []error, err error) {
+	unlock, err := LockOrders(a.locker, orderIDs)
+	if err != nil {
+		return 0, nil, err
+	}
+	batch, err := a.relinkBatch(orderIDs, customerID, pseudonym)
+	if unlockErr := unlock(); unlockErr != nil && err == nil {
+		err = unlockErr
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for _, order := range batch {
+		if err = a.dispatcher.Dispatch(model.OrderAnonymized{OrderID: order.ID}); err != nil {
+			dispatchErrs = append(dispatchErrs, err)
+		}
+	}
+	return len(batch), dispatchErrs, nil
+}
+
+// relinkBatch reads orders under lock, orders deleted or relinked since they were streamed are skipped
+func (a *customerAnonymizer) relinkBatch(orderIDs []uuid.UUID, customerID, pseudonym uuid.UUID) ([]*model.Order, error) {
+	orders, err := a.repo.FindMany(orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	batch := make([]*model.Order, 0, len(orders))
+	batchIDs := make([]uuid.UUID, 0, len(orders))
+	for _, orderID := range orderIDs {
+		if order, ok := orders[orderID]; ok && order.CustomerID == customerID {
+			batch = append(batch, order)
+			batchIDs = append(batchIDs, orderID)
+		}
+	}
+	if len(batch) == 0 {
+		return nil, nil
+	}
+
+	// audited before relinking, otherwise orders relinked without record could not be found by a retry
+	err = a.audit.Record(ErasureRecord{
+		OrderIDs: batchIDs,
+		ErasedAt: a.clock.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, order := range batch {
+		order.CustomerID = pseudonym
+		// tags are free-form and may carry personal data
+		order.Tags = nil
+	}
+	if err = a.repo.StoreMany(batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+type OrderArchiver interface {
+	// Archive moves final orders not updated for olderThan to the archive and returns number of moved orders.
+	// An order is final when its status has no transitions to other statuses in the customer's transition table
+	Archive(olderThan time.Duration) (int, error)
+	// Restore moves archived order back to the OrderRepository
+	Restore(orderID uuid.UUID) error
+}
+
+// NewOrderArchiver takes transitions the order service is configured with.
+// Orders are streamed by statuses final in any of the tables when they are known, other transitions stream every status
+func NewOrderArchiver(repo model.OrderRepository, archive model.ArchiveRepository, transitions Transitions) OrderArchiver {
+	a := &orderArchiver{
+		repo:        repo,
+		archive:     archive,
+		transitions: transitions,
+	}
+	if t, ok := transitions.(interface{ tables() []TransitionTable }); ok {
+		a.candidates = finalStatusesOf(t.tables())
+		a.prefiltered = true
+	}
+	return a
+}
+
+type orderArchiver struct {
+	repo        model.OrderRepository
+	archive     model.ArchiveRepository
+	transitions Transitions
+	// candidates are statuses final in at least one table, valid when prefiltered
+	candidates  []model.OrderStatus
+	prefiltered bool
+}
+
+func (a *orderArchiver) Archive(olderThan time.Duration) (int, error) {
+	if a.prefiltered && len(a.candidates) == 0 {
+		return 0, nil
+	}
+	filter := model.OrderFilter{
+		Statuses:      a.candidates,
+		UpdatedBefore: time.Now().UTC().Add(-olderThan),
+	}
+
+	var orderIDs []uuid.UUID
+	err := a.repo.StreamOrders(filter, func(order *model.Order) error {
+		orderIDs = append(orderIDs, order.ID)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for _, orderID := range orderIDs {
+		order, err := a.repo.Find(orderID)
+		if err != nil {
+			return archived, err
+		}
+		if !isFinal(a.transitions.For(order.CustomerID), order.Status) {
+			continue
+		}
+		if err = a.archive.Store(order); err != nil {
+			return archived, err
+		}
+		if err = a.repo.Purge(orderID); err != nil {
+			return archived, err
+		}
+		archived++
+	}
+
+	return archived, nil
+}
+
+func (a *orderArchiver) Restore(orderID uuid.UUID) error {
+	order, err := a.archive.Find(orderID)
+	if err != nil {
+		return err
+	}
+	if err = a.repo.Store(order); err != nil {
+		return err
+	}
+	return a.archive.Delete(orderID)
+}
+
+// NewArchiveReadThroughRepository falls back to the archive for orders missing in repo,
+// archived orders changed through it are stored back to repo. Deleting an archived order moves it back to repo
+// before it is marked deleted, purging removes it from the archive
+func NewArchiveReadThroughRepository(repo model.OrderRepository, archive model.ArchiveRepository) model.OrderRepository {
+	return &archiveReadThroughRepository{
+		OrderRepository: repo,
+		archive:         archive,
+	}
+}
+
+type archiveReadThroughRepository struct {
+	model.OrderRepository
+	archive model.ArchiveRepository
+}
+
+func (r *archiveReadThroughRepository) Find(id uuid.UUID) (*model.Order, error) {
+	order, err := r.OrderRepository.Find(id)
+	if errors.Is(err, model.ErrOrderNotFound) {
+		return r.archive.Find(id)
+	}
+	return order, err
+}
+
+func (r *archiveReadThroughRepository) FindMany(ids []uuid.UUID) (map[uuid.UUID]*model.Order, error) {
+	orders, err := r.OrderRepository.FindMany(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if _, ok := orders[id]; ok {
+			continue
+		}
+		order, err := r.archive.Find(id)
+		if errors.Is(err, model.ErrOrderNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		orders[id] = order
+	}
+	return orders, nil
+}
+
+func (r *archiveReadThroughRepository) Delete(id uuid.UUID) error {
+	err := r.OrderRepository.Delete(id)
+	if !errors.Is(err, model.ErrOrderNotFound) {
+		return err
+	}
+
+	order, err := r.archive.Find(id)
+	if err != nil {
+		return err
+	}
+	if err = r.OrderRepository.Store(order); err != nil {
+		return err
+	}
+	if err = r.archive.Delete(id); err != nil {
+		return err
+	}
+	return r.OrderRepository.Delete(id)
+}
+
+func (r *archiveReadThroughRepository) Purge(id uuid.UUID) error {
+	err := r.OrderRepository.Purge(id)
+	if !errors.Is(err, model.ErrOrderNotFound) {
+		return err
+	}
+
+	if _, err = r.archive.Find(id); err != nil {
+		return err
+	}
+	return r.archive.Delete(id)
+}
+
+// finalStatusesOf returns statuses an order can't leave in at least one of tables
+func finalStatusesOf(tables []TransitionTable) []model.OrderStatus {
+	var statuses []model.OrderStatus
+	for _, status := range []model.OrderStatus{model.Open, model.Pending, model.Paid, model.Cancelled} {
+		for _, table := range tables {
+			if isFinal(table, status) {
+				statuses = append(statuses, status)
+				break
+			}
+		}
+	}
+	return statuses
+}
+
+// isFinal reports whether an order can't leave status, transitions to the same status don't count
+func isFinal(table TransitionTable, status model.OrderStatus) bool {
+	for _, to := range table[status] {
+		if to != status {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+const bulkBatchSize = 100
+
+// ErrBulkNotAttempted is the result of orders left after the bulk stopped on a failed batch
+var ErrBulkNotAttempted = errors.New("order not attempted after bulk failure")
+
+type BulkStatusResult struct {
+	OrderID uuid.UUID
+	Err     error
+}
+
+type BulkOption func(o *bulkOptions)
+
+// WithConsolidatedEvent dispatches a single OrderStatusBulkChanged event instead of an event per order
+func WithConsolidatedEvent() BulkOption {
+	return func(o *bulkOptions) {
+		o.consolidatedEvent = true
+	}
+}
+
+type bulkOptions struct {
+	consolidatedEvent bool
+}
+
+func (o *orderService) SetStatusBulk(
+	orderIDs []uuid.UUID,
+	status model.OrderStatus,
+	opts ...BulkOption,
+) (results []BulkStatusResult, err error) {
+	// per-order errors are kept bare, results already carry order IDs
+	defer func() {
+		for _, result := range results {
+			if errors.Is(result.Err, ErrBulkNotAttempted) {
+				continue
+			}
+			o.afterCommand("SetStatusBulk", result.OrderID, result.Err)
+		}
+		o.wrapErr("orderService.SetStatusBulk", uuid.Nil, &err)
+	}()
+
+	var options bulkOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// repeated IDs would be transitioned and reported twice
+	orderIDs = uniqueIDs(orderIDs)
+	results = make([]BulkStatusResult, 0, len(orderIDs))
+	// changed holds indexes of stored orders in results, their results get the dispatch error when it fails
+	var (
+		changed      []int
+		dispatchErrs []error
+	)
+	for start := 0; start < len(orderIDs); start += bulkBatchSize {
+		end := min(start+bulkBatchSize, len(orderIDs))
+		batchIDs := orderIDs[start:end]
+
+		batchResults, batchErr := o.setStatusBatch(batchIDs, status)
+		offset := len(results)
+		results = append(results, batchResults...)
+		if batchErr != nil {
+			dispatchErrs = append(dispatchErrs, batchErr)
+			results = appendNotAttempted(results, orderIDs[end:])
+			break
+		}
+
+		// stored orders stay changed, so dispatching goes on after a failure and every lost event is reported
+		for i := offset; i < len(results); i++ {
+			if results[i].Err != nil {
+				continue
+			}
+			if options.consolidatedEvent {
+				changed = append(changed, i)
+				continue
+			}
+			if dispatchErr := o.dispatcher.Dispatch(model.OrderStatusChanged{
+				OrderID:   results[i].OrderID,
+				NewStatus: status,
+			}); dispatchErr != nil {
+				results[i].Err = dispatchErr
+				dispatchErrs = append(dispatchErrs, dispatchErr)
+			}
+		}
+	}
+
+	if len(changed) > 0 {
+		changedIDs := make([]uuid.UUID, 0, len(changed))
+		for _, i := range changed {
+			changedIDs = append(changedIDs, results[i].OrderID)
+		}
+		if dispatchErr := o.dispatcher.Dispatch(model.OrderStatusBulkChanged{
+			OrderIDs:  changedIDs,
+			NewStatus: status,
+		}); dispatchErr != nil {
+			for _, i := range changed {
+				results[i].Err = dispatchErr
+			}
+			dispatchErrs = append(dispatchErrs, dispatchErr)
+		}
+	}
+
+	return results, errors.Join(dispatchErrs...)
+}
+
+// setStatusBatch returns result for every order of the batch, results without error are stored orders.
+// Error is returned only when the batch can't be stored
+func (o *orderService) setStatusBatch(
+	orderIDs []uuid.UUID,
+	status model.OrderStatus,
+) ([]BulkStatusResult, error) {
+	orders, err := o.repo.FindMany(orderIDs)
+	if err != nil {
+		results := make([]BulkStatusResult, 0, len(orderIDs))
+		for _, orderID := range orderIDs {
+			results = append(results, BulkStatusResult{OrderID: orderID, Err: err})
+		}
+		return results, err
+	}
+
+	currentTime := o.options.clock.Now()
+	results := make([]BulkStatusResult, 0, len(orderIDs))
+	changed := make([]*model.Order, 0, len(orders))
+	for _, orderID := range orderIDs {
+		if hookErr := o.beforeCommand("SetStatusBulk", orderID); hookErr != nil {
+			results = append(results, BulkStatusResult{OrderID: orderID, Err: hookErr})
+			continue
+		}
+
+		order, ok := orders[orderID]
+		if !ok {
+			results = append(results, BulkStatusResult{OrderID: orderID, Err: model.ErrOrderNotFound})
+			continue
+		}
+		if limitErr := o.allowCustomer(order.CustomerID); limitErr != nil {
+			results = append(results, BulkStatusResult{OrderID: orderID, Err: limitErr})
+			continue
+		}
+		if !o.transitionsFor(order.CustomerID).Allowed(order.Status, status) {
+			results = append(results, BulkStatusResult{OrderID: orderID, Err: ErrInvalidOrderStatus})
+			continue
+		}
+
+		order.Status = status
+		order.UpdatedAt = currentTime
+		changed = append(changed, order)
+		results = append(results, BulkStatusResult{OrderID: orderID})
+	}
+
+	if len(changed) == 0 {
+		return results, nil
+	}
+	if err = o.repo.StoreMany(changed); err != nil {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = err
+			}
+		}
+		return results, err
+	}
+
+	return results, nil
+}
+
+func appendNotAttempted(results []BulkStatusResult, orderIDs []uuid.UUID) []BulkStatusResult {
+	for _, orderID := range orderIDs {
+		results = append(results, BulkStatusResult{OrderID: orderID, Err: ErrBulkNotAttempted})
+	}
+	return results
+}
+
+// uniqueIDs keeps the first occurrence of every ID
+func uniqueIDs(orderIDs []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(orderIDs))
+	return slices.DeleteFunc(slices.Clone(orderIDs), func(orderID uuid.UUID) bool {
+		if _, ok := seen[orderID]; ok {
+			return true
+		}
+		seen[orderID] = struct{}{}
+		return false
+	})
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+// CustomerStatsProjection maintains per-customer rollups from order events and serves them without scanning orders
+type CustomerStatsProjection interface {
+	EventDispatcher
+	CustomerRelinker
+	GetCustomerStats(customerID uuid.UUID) (*model.CustomerStats, error)
+}
+
+func NewCustomerStatsProjection(
+	orders model.OrderRepository,
+	stats model.CustomerStatsRepository,
+) CustomerStatsProjection {
+	return &customerStatsProjection{
+		orders: orders,
+		stats:  stats,
+	}
+}
+
+type customerStatsProjection struct {
+	orders model.OrderRepository
+	stats  model.CustomerStatsRepository
+}
+
+// Dispatch counts spend of orders which are Paid now. Spend is set per order, so repeated events don't count
+// an order twice and an order leaving Paid is no longer counted
+func (p *customerStatsProjection) Dispatch(event Event) error {
+	switch e := event.(type) {
+	case model.OrderCreated:
+		order, err := p.orders.Find(e.OrderID)
+		if err != nil {
+			return err
+		}
+		return p.stats.RecordOrder(e.CustomerID, order.CreatedAt)
+	case model.OrderStatusChanged:
+		order, err := p.orders.Find(e.OrderID)
+		if err != nil {
+			return err
+		}
+		return p.recordSpend(order)
+	case model.OrderStatusBulkChanged:
+		orders, err := p.orders.FindMany(e.OrderIDs)
+		if err != nil {
+			return err
+		}
+		var errs []error
+		for _, order := range orders {
+			errs = append(errs, p.recordSpend(order))
+		}
+		return errors.Join(errs...)
+	default:
+		return nil
+	}
+}
+
+func (p *customerStatsProjection) GetCustomerStats(customerID uuid.UUID) (*model.CustomerStats, error) {
+	stats, err := p.stats.Find(customerID)
+	if errors.Is(err, model.ErrCustomerStatsNotFound) {
+		return &model.CustomerStats{CustomerID: customerID}, nil
+	}
+	return stats, err
+}
+
+func (p *customerStatsProjection) RelinkCustomer(customerID, pseudonym uuid.UUID) error {
+	return p.stats.Relink(customerID, pseudonym)
+}
+
+// recordSpend uses current status of the order, so a stale event can't bring back spend of an order that left Paid
+func (p *customerStatsProjection) recordSpend(order *model.Order) error {
+	var total float64
+	if order.Status == model.Paid {
+		for _, item := range order.Items {
+			total += item.Price
+		}
+	}
+	return p.stats.SetOrderSpend(order.CustomerID, order.ID, total)
+}
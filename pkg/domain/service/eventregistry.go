@@ -0,0 +1,58 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+var ErrUnknownEventType = errors.New("unknown event type")
+
+var eventRegistry = struct {
+	sync.RWMutex
+	decoders map[string]func(data []byte) (Event, error)
+}{
+	decoders: map[string]func(data []byte) (Event, error){},
+}
+
+func init() {
+	RegisterEvent[model.OrderCreated](model.OrderCreated{}.Type())
+	RegisterEvent[model.OrderItemsChanged](model.OrderItemsChanged{}.Type())
+	RegisterEvent[model.OrderStatusChanged](model.OrderStatusChanged{}.Type())
+	RegisterEvent[model.OrderStatusBulkChanged](model.OrderStatusBulkChanged{}.Type())
+	RegisterEvent[model.OrderDeleted](model.OrderDeleted{}.Type())
+	RegisterEvent[model.OrderAnonymized](model.OrderAnonymized{}.Type())
+	RegisterEvent[model.OrderTagsChanged](model.OrderTagsChanged{}.Type())
+}
+
+// RegisterEvent makes events of type T decodable by name, registering the same name again replaces the decoder
+func RegisterEvent[T Event](name string) {
+	eventRegistry.Lock()
+	defer eventRegistry.Unlock()
+
+	eventRegistry.decoders[name] = func(data []byte) (Event, error) {
+		var event T
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
+
+func DecodeEvent(name string, data []byte) (Event, error) {
+	eventRegistry.RLock()
+	decode, ok := eventRegistry.decoders[name]
+	eventRegistry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, name)
+	}
+
+	event, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import "github.com/google/uuid"
+
+type FeatureFlags interface {
+	Enabled(flag string, customerID uuid.UUID) bool
+}
+
+// WithFeatureFlags sets provider consulted by gated options, gated behaviors stay off without it
+func WithFeatureFlags(flags FeatureFlags) Option {
+	return func(o *options) {
+		o.flags = flags
+	}
+}
+
+// WithGatedItemValidators runs validators in addition to the regular ones for customers the flag is enabled for
+func WithGatedItemValidators(flag string, validators ...ItemValidator) Option {
+	return func(o *options) {
+		o.gatedValidators = append(o.gatedValidators, gatedItemValidators{flag: flag, validators: validators})
+	}
+}
+
+// TransitionGate replaces the regular transition table with Table for customers Flag is enabled for
+type TransitionGate struct {
+	Flag  string
+	Table TransitionTable
+}
+
+// NewGatedTransitions picks the table of the first gate enabled for the customer and regular otherwise.
+// Pass the result to both WithTransitions and NewOrderArchiver so they agree on which orders are final
+func NewGatedTransitions(regular TransitionTable, flags FeatureFlags, gates ...TransitionGate) Transitions {
+	return &gatedTransitions{
+		regular: regular,
+		flags:   flags,
+		gates:   gates,
+	}
+}
+
+type gatedTransitions struct {
+	regular TransitionTable
+	flags   FeatureFlags
+	gates   []TransitionGate
+}
+
+func (g *gatedTransitions) For(customerID uuid.UUID) TransitionTable {
+	for _, gate := range g.gates {
+		if g.flags != nil && g.flags.Enabled(gate.Flag, customerID) {
+			return gate.Table
+		}
+	}
+	return g.regular
+}
+
+func (g *gatedTransitions) tables() []TransitionTable {
+	tables := []TransitionTable{g.regular}
+	for _, gate := range g.gates {
+		tables = append(tables, gate.Table)
+	}
+	return tables
+}
+
+type gatedItemValidators struct {
+	flag       string
+	validators []ItemValidator
+}
+
+func (o *orderService) enabled(flag string, customerID uuid.UUID) bool {
+	return o.options.flags != nil && o.options.flags.Enabled(flag, customerID)
+}
+
+func (o *orderService) transitionsFor(customerID uuid.UUID) TransitionTable {
+	return o.options.transitions.For(customerID)
+}
+
+func (o *orderService) itemValidatorsFor(customerID uuid.UUID) []ItemValidator {
+	validators := o.options.itemValidators
+	for _, gated := range o.options.gatedValidators {
+		if o.enabled(gated.flag, customerID) {
+			validators = append(validators[:len(validators):len(validators)], gated.validators...)
+		}
+	}
+	return validators
+}
@@ -0,0 +1,38 @@
+package service
+
+import "github.com/google/uuid"
+
+// Hook observes commands of the order service, queries are not hooked
+type Hook interface {
+	// BeforeCommand rejects the command when it returns an error, orderID is uuid.Nil for CreateOrder
+	BeforeCommand(command string, orderID uuid.UUID) error
+	// AfterCommand is called for every command including rejected ones, err is the result of the command
+	AfterCommand(command string, orderID uuid.UUID, err error)
+}
+
+// WithHooks registers hooks, they are called in registration order, SetStatusBulk calls them per order
+func WithHooks(hooks ...Hook) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, hooks...)
+	}
+}
+
+func (o *orderService) beforeCommand(command string, orderID uuid.UUID) error {
+	for _, hook := range o.options.hooks {
+		if err := hook.BeforeCommand(command, orderID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *orderService) afterCommand(command string, orderID uuid.UUID, err error) {
+	for _, hook := range o.options.hooks {
+		hook.AfterCommand(command, orderID, err)
+	}
+}
+
+func (o *orderService) finishCommand(command string, orderID uuid.UUID, err *error) {
+	o.afterCommand(command, orderID, *err)
+	o.wrapErr("orderService."+command, orderID, err)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+const defaultImportBatchSize = 500
+
+var (
+	ErrNilImportOrder = errors.New("nil order in import")
+	// ErrImportEventsNotDispatched means the orders are stored and counted as imported, but some events were lost
+	ErrImportEventsNotDispatched = errors.New("imported orders events not dispatched")
+)
+
+type OrderImporter interface {
+	// Import stores orders in batches and dispatches their OrderCreated events only after the batch is stored.
+	// It returns number of imported orders, orders stored before an error stay imported and are counted
+	// even when dispatching their events fails with ErrImportEventsNotDispatched
+	Import(orders iter.Seq[*model.Order]) (int, error)
+}
+
+func NewOrderImporter(repo model.OrderRepository, dispatcher EventDispatcher, batchSize int) OrderImporter {
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+	return &orderImporter{
+		repo:       repo,
+		dispatcher: dispatcher,
+		batchSize:  batchSize,
+	}
+}
+
+type orderImporter struct {
+	repo       model.OrderRepository
+	dispatcher EventDispatcher
+	batchSize  int
+}
+
+func (i *orderImporter) Import(orders iter.Seq[*model.Order]) (int, error) {
+	imported := 0
+	batch := make([]*model.Order, 0, i.batchSize)
+	for order := range orders {
+		if err := i.prepare(order); err != nil {
+			return imported, err
+		}
+
+		batch = append(batch, order)
+		if len(batch) < i.batchSize {
+			continue
+		}
+		stored, err := i.flush(batch)
+		imported += stored
+		if err != nil {
+			return imported, err
+		}
+		batch = batch[:0]
+	}
+
+	if len(batch) > 0 {
+		stored, err := i.flush(batch)
+		imported += stored
+		if err != nil {
+			return imported, err
+		}
+	}
+
+	return imported, nil
+}
+
+func (i *orderImporter) prepare(order *model.Order) error {
+	if order == nil {
+		return ErrNilImportOrder
+	}
+	if order.ID == uuid.Nil {
+		orderID, err := i.repo.NextID()
+		if err != nil {
+			return err
+		}
+		order.ID = orderID
+	}
+
+	if order.CreatedAt.IsZero() {
+		order.CreatedAt = time.Now().UTC()
+	}
+	if order.UpdatedAt.IsZero() {
+		order.UpdatedAt = order.CreatedAt
+	}
+	return nil
+}
+
+// flush returns number of stored orders, events of the stored batch are dispatched even after one of them fails
+func (i *orderImporter) flush(batch []*model.Order) (int, error) {
+	if err := i.repo.StoreMany(batch); err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, order := range batch {
+		err := i.dispatcher.Dispatch(model.OrderCreated{
+			OrderID:    order.ID,
+			CustomerID: order.CustomerID,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return len(batch), fmt.Errorf("%w: %w", ErrImportEventsNotDispatched, errors.Join(errs...))
+	}
+	return len(batch), nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+// DistributedLocker serializes work on a key across service replicas
+type DistributedLocker interface {
+	Lock(key string) (unlock func() error, err error)
+}
+
+var ErrLeaseLost = errors.New("lock lease lost")
+
+// Lease is a held lock which can be lost without unlock, e.g. when the session holding it ends
+type Lease interface {
+	// Check fails with ErrLeaseLost when the lock is not held anymore, other errors mean it can't be confirmed
+	Check() error
+	Release() error
+}
+
+// LeaseLocker hands out locks for long-running holders which have to confirm they still hold them
+type LeaseLocker interface {
+	LockLease(key string) (Lease, error)
+}
+
+// MultiLocker is implemented by lockers which hold a limited resource per lock, e.g. a connection,
+// so many keys can be locked at once without holding a resource per key
+type MultiLocker interface {
+	// LockMany takes locks in the given order and releases all of them with one unlock
+	LockMany(keys []string) (unlock func() error, err error)
+}
+
+// NewLockingMiddleware serializes mutations of a single order across replicas, CreateOrder is not locked
+func NewLockingMiddleware(locker DistributedLocker) Middleware {
+	return func(next Order) Order {
+		return &lockingOrderService{
+			Order:  next,
+			locker: locker,
+		}
+	}
+}
+
+type lockingOrderService struct {
+	Order
+	locker DistributedLocker
+}
+
+func (s *lockingOrderService) DeleteOrder(orderID uuid.UUID) error {
+	return s.withLock(orderID, func() error {
+		return s.Order.DeleteOrder(orderID)
+	})
+}
+
+func (s *lockingOrderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) error {
+	return s.withLock(orderID, func() error {
+		return s.Order.SetStatus(orderID, status)
+	})
+}
+
+func (s *lockingOrderService) SetStatusBulk(
+	orderIDs []uuid.UUID,
+	status model.OrderStatus,
+	opts ...BulkOption,
+) (results []BulkStatusResult, err error) {
+	unlock, err := LockOrders(s.locker, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	return s.Order.SetStatusBulk(orderIDs, status, opts...)
+}
+
+func (s *lockingOrderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (itemID uuid.UUID, err error) {
+	err = s.withLock(orderID, func() error {
+		itemID, err = s.Order.AddItem(orderID, productID, price)
+		return err
+	})
+	return itemID, err
+}
+
+func (s *lockingOrderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error {
+	return s.withLock(orderID, func() error {
+		return s.Order.DeleteItem(orderID, itemID)
+	})
+}
+
+func (s *lockingOrderService) AddTag(orderID uuid.UUID, tag string) error {
+	return s.withLock(orderID, func() error {
+		return s.Order.AddTag(orderID, tag)
+	})
+}
+
+func (s *lockingOrderService) RemoveTag(orderID uuid.UUID, tag string) error {
+	return s.withLock(orderID, func() error {
+		return s.Order.RemoveTag(orderID, tag)
+	})
+}
+
+func (s *lockingOrderService) withLock(orderID uuid.UUID, f func() error) (err error) {
+	unlock, err := s.locker.Lock(orderLockKey(orderID))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	return f()
+}
+
+// lockOrders locks orders in a stable order so concurrent multi-order calls can't deadlock each other
+func LockOrders(locker DistributedLocker, orderIDs []uuid.UUID) (func() error, error) {
+	sortedIDs := slices.Clone(orderIDs)
+	slices.SortFunc(sortedIDs, func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	})
+	sortedIDs = slices.Compact(sortedIDs)
+
+	keys := make([]string, 0, len(sortedIDs))
+	for _, orderID := range sortedIDs {
+		keys = append(keys, orderLockKey(orderID))
+	}
+	return lockMany(locker, keys)
+}
+
+// lockMany takes keys in the given order, locks taken before a failure are released
+func lockMany(locker DistributedLocker, keys []string) (func() error, error) {
+	if multiLocker, ok := locker.(MultiLocker); ok {
+		return multiLocker.LockMany(keys)
+	}
+
+	unlocks := make([]func() error, 0, len(keys))
+	unlockAll := func() error {
+		var err error
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			if unlockErr := unlocks[i](); unlockErr != nil && err == nil {
+				err = unlockErr
+			}
+		}
+		return err
+	}
+	for _, key := range keys {
+		unlock, err := locker.Lock(key)
+		if err != nil {
+			_ = unlockAll()
+			return nil, err
+		}
+		unlocks = append(unlocks, unlock)
+	}
+	return unlockAll, nil
+}
+
+func orderLockKey(orderID uuid.UUID) string {
+	return "order:" + orderID.String()
+}
@@ -0,0 +1,13 @@
+package service
+
+// Middleware decorates Order service with additional behaviour (logging, metrics, retries and etc.)
+type Middleware = func(Order) Order
+
+// Chain wraps base with middlewares, the first middleware becomes the outermost one
+func Chain(base Order, mws ...Middleware) Order {
+	result := base
+	for i := len(mws) - 1; i >= 0; i-- {
+		result = mws[i](result)
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+var ErrItemsLimitExceeded = errors.New("order items limit exceeded")
+
+type Clock interface {
+	Now() time.Time
+}
+
+type IDGenerator interface {
+	NextID() (uuid.UUID, error)
+}
+
+// ItemValidator rejects items before they are added to the order
+type ItemValidator func(order *model.Order, item model.Item) error
+
+// TransitionTable lists statuses reachable from each status
+type TransitionTable map[model.OrderStatus][]model.OrderStatus
+
+func (t TransitionTable) Allowed(from, to model.OrderStatus) bool {
+	return slices.Contains(t[from], to)
+}
+
+// For returns the table itself, it applies to every customer
+func (t TransitionTable) For(uuid.UUID) TransitionTable {
+	return t
+}
+
+func (t TransitionTable) tables() []TransitionTable {
+	return []TransitionTable{t}
+}
+
+// Transitions picks transition table applying to the customer
+type Transitions interface {
+	For(customerID uuid.UUID) TransitionTable
+}
+
+// DefaultTransitionTable allows any transition except leaving Cancelled
+func DefaultTransitionTable() TransitionTable {
+	all := []model.OrderStatus{model.Open, model.Pending, model.Paid, model.Cancelled}
+	return TransitionTable{
+		model.Open:    all,
+		model.Pending: all,
+		model.Paid:    all,
+	}
+}
+
+type Option func(o *options)
+
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithIDGenerator replaces repository NextID for order and item IDs
+func WithIDGenerator(generator IDGenerator) Option {
+	return func(o *options) {
+		o.idGenerator = generator
+	}
+}
+
+func WithItemValidators(validators ...ItemValidator) Option {
+	return func(o *options) {
+		o.itemValidators = append(o.itemValidators, validators...)
+	}
+}
+
+func WithTransitionTable(table TransitionTable) Option {
+	return WithTransitions(table)
+}
+
+// WithTransitions chooses transition table per customer, e.g. with NewGatedTransitions
+func WithTransitions(transitions Transitions) Option {
+	return func(o *options) {
+		o.transitions = transitions
+	}
+}
+
+// WithMaxItemsPerOrder limits number of items in an order, zero means no limit
+func WithMaxItemsPerOrder(limit int) Option {
+	return func(o *options) {
+		o.maxItemsPerOrder = limit
+	}
+}
+
+// WithErrorStackTraces captures stack trace in every error returned by the service, it is printed with %+v
+func WithErrorStackTraces() Option {
+	return func(o *options) {
+		o.errorStackTraces = true
+	}
+}
+
+type options struct {
+	clock            Clock
+	idGenerator      IDGenerator
+	itemValidators   []ItemValidator
+	transitions      Transitions
+	maxItemsPerOrder int
+	errorStackTraces bool
+	hooks            []Hook
+	rateLimit        *customerRateLimit
+	openOrdersQuota  *openOrdersQuota
+	flags            FeatureFlags
+	gatedValidators  []gatedItemValidators
+	pricing          PricingStrategy
+	tagLimits        *tagLimits
+}
+
+type utcClock struct{}
+
+func (utcClock) Now() time.Time {
+	return time.Now().UTC()
+}
@@ -2,11 +2,11 @@ package service
 
 import (
 	"errors"
-	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/internal/errs"
 )
 
 var (
@@ -22,40 +22,75 @@ type EventDispatcher interface {
 	Dispatch(event Event) error
 }
 
+// Order combines both sides for consumers that need full access
 type Order interface {
-	CreateOrder(customerID uuid.UUID) (uuid.UUID, error)
+	OrderCommands
+	OrderQueries
+}
+
+type OrderCommands interface {
+	CreateOrder(customerID uuid.UUID, opts ...CreateOption) (uuid.UUID, error)
 	DeleteOrder(orderID uuid.UUID) error
 	SetStatus(orderID uuid.UUID, status model.OrderStatus) error
+	SetStatusBulk(orderIDs []uuid.UUID, status model.OrderStatus, opts ...BulkOption) ([]BulkStatusResult, error)
 	AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (uuid.UUID, error)
 	DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error
+	AddTag(orderID uuid.UUID, tag string) error
+	RemoveTag(orderID uuid.UUID, tag string) error
+}
+
+// OrderQueries never changes orders, read-only consumers should depend on it
+type OrderQueries interface {
+	GetOrder(orderID uuid.UUID) (*model.Order, error)
 }
 
-func NewOrderService(repo model.OrderRepository, dispatcher EventDispatcher) Order {
+func NewOrderService(repo model.OrderRepository, dispatcher EventDispatcher, opts ...Option) Order {
+	o := options{
+		clock:       utcClock{},
+		idGenerator: repo,
+		transitions: DefaultTransitionTable(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return &orderService{
 		repo:       repo,
 		dispatcher: dispatcher,
+		options:    o,
 	}
 }
 
 type orderService struct {
 	repo       model.OrderRepository
 	dispatcher EventDispatcher
+	options    options
 }
 
-func (o *orderService) CreateOrder(customerID uuid.UUID) (uuid.UUID, error) {
-	orderID, err := o.repo.NextID()
+func (o *orderService) CreateOrder(customerID uuid.UUID, opts ...CreateOption) (orderID uuid.UUID, err error) {
+	defer func() {
+		o.finishCommand("CreateOrder", orderID, &err)
+	}()
+	if err = o.beforeCommand("CreateOrder", uuid.Nil); err != nil {
+		return uuid.Nil, err
+	}
+	if err = o.allowCustomer(customerID); err != nil {
+		return uuid.Nil, err
+	}
+
+	orderID, err = o.options.idGenerator.NextID()
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	currentTime := time.Now().UTC()
-	err = o.repo.Store(&model.Order{
+	currentTime := o.options.clock.Now()
+	err = o.storeNewOrder(&model.Order{
 		ID:         orderID,
 		CustomerID: customerID,
 		Status:     model.Open,
 		CreatedAt:  currentTime,
 		UpdatedAt:  currentTime,
-	})
+	}, opts)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -66,11 +101,19 @@ func (o *orderService) CreateOrder(customerID uuid.UUID) (uuid.UUID, error) {
 	})
 }
 
-func (o *orderService) DeleteOrder(orderID uuid.UUID) error {
-	_, err := o.repo.Find(orderID)
+func (o *orderService) DeleteOrder(orderID uuid.UUID) (err error) {
+	defer o.finishCommand("DeleteOrder", orderID, &err)
+	if err = o.beforeCommand("DeleteOrder", orderID); err != nil {
+		return err
+	}
+
+	order, err := o.repo.Find(orderID)
 	if err != nil {
 		return err
 	}
+	if err = o.allowCustomer(order.CustomerID); err != nil {
+		return err
+	}
 
 	if err := o.repo.Delete(orderID); err != nil {
 		return err
@@ -81,18 +124,26 @@ func (o *orderService) DeleteOrder(orderID uuid.UUID) error {
 	})
 }
 
-func (o *orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) error {
+func (o *orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) (err error) {
+	defer o.finishCommand("SetStatus", orderID, &err)
+	if err = o.beforeCommand("SetStatus", orderID); err != nil {
+		return err
+	}
+
 	order, err := o.repo.Find(orderID)
 	if err != nil {
 		return err
 	}
+	if err = o.allowCustomer(order.CustomerID); err != nil {
+		return err
+	}
 
-	if order.Status == model.Cancelled {
+	if !o.transitionsFor(order.CustomerID).Allowed(order.Status, status) {
 		return ErrInvalidOrderStatus
 	}
 
 	order.Status = status
-	order.UpdatedAt = time.Now().UTC()
+	order.UpdatedAt = o.options.clock.Now()
 
 	if err := o.repo.Store(order); err != nil {
 		return err
@@ -104,26 +155,53 @@ func (o *orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) er
 	})
 }
 
-func (o *orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (uuid.UUID, error) {
+func (o *orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (_ uuid.UUID, err error) {
+	defer o.finishCommand("AddItem", orderID, &err)
+	if err = o.beforeCommand("AddItem", orderID); err != nil {
+		return uuid.Nil, err
+	}
+
 	order, err := o.repo.Find(orderID)
 	if err != nil {
 		return uuid.Nil, err
 	}
+	if err = o.allowCustomer(order.CustomerID); err != nil {
+		return uuid.Nil, err
+	}
 
 	if order.Status != model.Open {
 		return uuid.Nil, ErrInvalidOrderStatus
 	}
 
-	itemID, err := o.repo.NextID()
+	if o.options.maxItemsPerOrder > 0 && len(order.Items) >= o.options.maxItemsPerOrder {
+		return uuid.Nil, ErrItemsLimitExceeded
+	}
+
+	itemID, err := o.options.idGenerator.NextID()
 	if err != nil {
 		return uuid.Nil, err
 	}
-	order.Items = append(order.Items, model.Item{
+	item := model.Item{
 		ID:        itemID,
 		ProductID: productID,
 		Price:     price,
-	})
-	order.UpdatedAt = time.Now().UTC()
+	}
+	if o.options.pricing != nil {
+		quote, err := o.options.pricing.Price(order, productID, price)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		item.Price = quote.UnitPrice
+		item.Adjustments = quote.Adjustments
+	}
+	for _, validator := range o.itemValidatorsFor(order.CustomerID) {
+		if err = validator(order, item); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	order.Items = append(order.Items, item)
+	order.UpdatedAt = o.options.clock.Now()
 
 	err = o.repo.Store(order)
 	if err != nil {
@@ -136,11 +214,19 @@ func (o *orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price flo
 	})
 }
 
-func (o *orderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error {
+func (o *orderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) (err error) {
+	defer o.finishCommand("DeleteItem", orderID, &err)
+	if err = o.beforeCommand("DeleteItem", orderID); err != nil {
+		return err
+	}
+
 	order, err := o.repo.Find(orderID)
 	if err != nil {
 		return err
 	}
+	if err = o.allowCustomer(order.CustomerID); err != nil {
+		return err
+	}
 
 	if order.Status != model.Open {
 		return ErrInvalidOrderStatus
@@ -159,7 +245,7 @@ func (o *orderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error {
 	}
 
 	order.Items = append(order.Items[:itemIndex], order.Items[itemIndex+1:]...)
-	order.UpdatedAt = time.Now().UTC()
+	order.UpdatedAt = o.options.clock.Now()
 
 	err = o.repo.Store(order)
 	if err != nil {
@@ -171,3 +257,17 @@ func (o *orderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error {
 		RemovedItems: []uuid.UUID{itemID},
 	})
 }
+
+func (o *orderService) GetOrder(orderID uuid.UUID) (_ *model.Order, err error) {
+	defer o.wrapErr("orderService.GetOrder", orderID, &err)
+
+	return o.repo.Find(orderID)
+}
+
+func (o *orderService) wrapErr(op string, orderID uuid.UUID, err *error) {
+	if o.options.errorStackTraces {
+		*err = errs.WrapWithStack(op, orderID, *err)
+		return
+	}
+	*err = errs.Wrap(op, orderID, *err)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+type PriceQuote struct {
+	UnitPrice   float64
+	Adjustments []model.PriceAdjustment
+}
+
+type PricingStrategy interface {
+	// Price returns final price of the product added to order, basePrice is the price passed to AddItem
+	Price(order *model.Order, productID uuid.UUID, basePrice float64) (PriceQuote, error)
+}
+
+// WithPricingStrategy prices items in AddItem before item validators run, adjustments are stored on the item
+func WithPricingStrategy(strategy PricingStrategy) Option {
+	return func(o *options) {
+		o.pricing = strategy
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import "github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+
+type CreateOption func(o *createOptions)
+
+// WithQuotaOverride skips open orders quota, it is meant for admin callers only
+func WithQuotaOverride() CreateOption {
+	return func(o *createOptions) {
+		o.quotaOverride = true
+	}
+}
+
+type createOptions struct {
+	quotaOverride bool
+}
+
+// WithOpenOrdersQuota rejects CreateOrder with model.ErrOpenOrdersLimitExceeded when customer already has limit open orders,
+// store is usually the order repository itself, it checks the limit atomically so concurrent calls can't exceed it
+func WithOpenOrdersQuota(store model.OpenOrdersLimitedStore, limit int) Option {
+	return func(o *options) {
+		o.openOrdersQuota = &openOrdersQuota{
+			store: store,
+			limit: limit,
+		}
+	}
+}
+
+type openOrdersQuota struct {
+	store model.OpenOrdersLimitedStore
+	limit int
+}
+
+func (o *orderService) storeNewOrder(order *model.Order, opts []CreateOption) error {
+	var createOpts createOptions
+	for _, opt := range opts {
+		opt(&createOpts)
+	}
+
+	quota := o.options.openOrdersQuota
+	if quota == nil || createOpts.quotaOverride {
+		return o.repo.Store(order)
+	}
+	return quota.store.StoreWithOpenOrdersLimit(order, quota.limit)
+}
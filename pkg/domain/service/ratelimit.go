@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrRateLimited = errors.New("customer rate limit exceeded")
+
+// RateLimitStore keeps fixed window counters, it must be shared by replicas to limit across them
+type RateLimitStore interface {
+	// Increment increments counter of key in the window starting at windowStart and returns the new value,
+	// counter may be dropped after window passes
+	Increment(key string, windowStart time.Time, window time.Duration) (int64, error)
+}
+
+// WithCustomerRateLimit allows at most limit mutations of orders of one customer per window,
+// SetStatusBulk counts every order and reports limited orders in results.
+// Nil store, non-positive limit or non-positive window means no limit
+func WithCustomerRateLimit(store RateLimitStore, limit int64, window time.Duration) Option {
+	return func(o *options) {
+		if store == nil || limit <= 0 || window <= 0 {
+			o.rateLimit = nil
+			return
+		}
+		o.rateLimit = &customerRateLimit{
+			store:  store,
+			limit:  limit,
+			window: window,
+		}
+	}
+}
+
+type customerRateLimit struct {
+	store  RateLimitStore
+	limit  int64
+	window time.Duration
+}
+
+func (o *orderService) allowCustomer(customerID uuid.UUID) error {
+	rateLimit := o.options.rateLimit
+	if rateLimit == nil {
+		return nil
+	}
+
+	windowStart := o.options.clock.Now().Truncate(rateLimit.window)
+	count, err := rateLimit.store.Increment("customer:"+customerID.String(), windowStart, rateLimit.window)
+	if err != nil {
+		return err
+	}
+	if count > rateLimit.limit {
+		return ErrRateLimited
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+// SalesReportProjection maintains daily sales aggregates from order events, days are in UTC.
+// Deleted orders are no longer counted
+type SalesReportProjection interface {
+	EventDispatcher
+	GetDailySales(from, to time.Time) ([]model.SalesRow, error)
+	// GetWeeklySales rolls daily rows up to weeks starting on Monday, the week containing to is included
+	// unless to is its start
+	GetWeeklySales(from, to time.Time) ([]model.SalesRow, error)
+}
+
+func NewSalesReportProjection(orders model.OrderRepository, report model.SalesReportRepository) SalesReportProjection {
+	return &salesReportProjection{
+		orders: orders,
+		report: report,
+	}
+}
+
+type salesReportProjection struct {
+	orders model.OrderRepository
+	report model.SalesReportRepository
+}
+
+// Dispatch records current status of the order and uses order timestamps as time of the change,
+// so repeated or replayed events count an order once and land on the right days
+func (p *salesReportProjection) Dispatch(event Event) error {
+	switch e := event.(type) {
+	case model.OrderCreated:
+		order, err := p.orders.Find(e.OrderID)
+		if err != nil {
+			return err
+		}
+		return p.record(order)
+	case model.OrderStatusChanged:
+		order, err := p.orders.Find(e.OrderID)
+		if err != nil {
+			return err
+		}
+		return p.record(order)
+	case model.OrderStatusBulkChanged:
+		orders, err := p.orders.FindMany(e.OrderIDs)
+		if err != nil {
+			return err
+		}
+		var errs []error
+		for _, order := range orders {
+			errs = append(errs, p.record(order))
+		}
+		return errors.Join(errs...)
+	case model.OrderDeleted:
+		return p.report.Remove(e.OrderID)
+	default:
+		return nil
+	}
+}
+
+func (p *salesReportProjection) GetDailySales(from, to time.Time) ([]model.SalesRow, error) {
+	return p.report.FindDaily(day(from), day(to))
+}
+
+func (p *salesReportProjection) GetWeeklySales(from, to time.Time) ([]model.SalesRow, error) {
+	end := week(to)
+	if !end.Equal(to) {
+		end = end.AddDate(0, 0, 7)
+	}
+	rows, err := p.report.FindDaily(week(from), end)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		week   time.Time
+		status model.OrderStatus
+	}
+	weekly := make(map[key]*model.SalesRow)
+	for _, row := range rows {
+		k := key{week: week(row.Period), status: row.Status}
+		aggregate, ok := weekly[k]
+		if !ok {
+			aggregate = &model.SalesRow{Period: k.week, Status: k.status}
+			weekly[k] = aggregate
+		}
+		aggregate.Orders += row.Orders
+		aggregate.Revenue += row.Revenue
+	}
+
+	result := make([]model.SalesRow, 0, len(weekly))
+	for _, row := range weekly {
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Period.Equal(result[j].Period) {
+			return result[i].Period.Before(result[j].Period)
+		}
+		return result[i].Status < result[j].Status
+	})
+	return result, nil
+}
+
+func (p *salesReportProjection) record(order *model.Order) error {
+	// open orders change on every item update, the day they entered Open is the day they were created
+	changedAt := order.UpdatedAt
+	if order.Status == model.Open {
+		changedAt = order.CreatedAt
+	}
+
+	var revenue float64
+	if order.Status == model.Paid {
+		for _, item := range order.Items {
+			revenue += item.Price
+		}
+	}
+	return p.report.Record(order.ID, day(changedAt), order.Status, revenue)
+}
+
+func day(t time.Time) time.Time {
+	year, month, d := t.UTC().Date()
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func week(t time.Time) time.Time {
+	d := day(t)
+	// time.Weekday starts on Sunday
+	return d.AddDate(0, 0, -(int(d.Weekday())+6)%7)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+var ErrInvalidMonitorInterval = errors.New("monitor interval must be positive")
+
+type StuckOrderFinder interface {
+	// FindStuck returns IDs of orders in status which were not updated since updatedBefore
+	FindStuck(status model.OrderStatus, updatedBefore time.Time) ([]uuid.UUID, error)
+}
+
+type StuckOrdersAlert struct {
+	Status    model.OrderStatus
+	Threshold time.Duration
+	OrderIDs  []uuid.UUID
+}
+
+type Alerter interface {
+	Alert(alert StuckOrdersAlert) error
+}
+
+type StuckOrderMonitor interface {
+	Check() error
+	Run(ctx context.Context, interval time.Duration, onError func(error)) error
+}
+
+func NewStuckOrderMonitor(
+	finder StuckOrderFinder,
+	alerter Alerter,
+	thresholds map[model.OrderStatus]time.Duration,
+) StuckOrderMonitor {
+	return &stuckOrderMonitor{
+		finder:     finder,
+		alerter:    alerter,
+		thresholds: thresholds,
+	}
+}
+
+type stuckOrderMonitor struct {
+	finder     StuckOrderFinder
+	alerter    Alerter
+	thresholds map[model.OrderStatus]time.Duration
+}
+
+func (m *stuckOrderMonitor) Check() error {
+	statuses := make([]model.OrderStatus, 0, len(m.thresholds))
+	for status := range m.thresholds {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i] < statuses[j]
+	})
+
+	now := time.Now().UTC()
+	var errs []error
+	for _, status := range statuses {
+		threshold := m.thresholds[status]
+		orderIDs, err := m.finder.FindStuck(status, now.Add(-threshold))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(orderIDs) == 0 {
+			continue
+		}
+
+		err = m.alerter.Alert(StuckOrdersAlert{
+			Status:    status,
+			Threshold: threshold,
+			OrderIDs:  orderIDs,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run checks orders every interval until ctx is done, check errors are passed to onError and do not stop the monitor.
+// Run fails only with ErrInvalidMonitorInterval
+func (m *stuckOrderMonitor) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		return ErrInvalidMonitorInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.Check(); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
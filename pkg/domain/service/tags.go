@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+var (
+	ErrTagsLimitExceeded = errors.New("order tags limit exceeded")
+	ErrTagNotFound       = errors.New("tag not found in order")
+)
+
+// WithMaxTagsPerOrder limits number of tags in an order, customerLimits override limit for their customers.
+// Zero means no limit
+func WithMaxTagsPerOrder(limit int, customerLimits map[uuid.UUID]int) Option {
+	return func(o *options) {
+		o.tagLimits = &tagLimits{
+			limit:          limit,
+			customerLimits: customerLimits,
+		}
+	}
+}
+
+type tagLimits struct {
+	limit          int
+	customerLimits map[uuid.UUID]int
+}
+
+func (l *tagLimits) limitFor(customerID uuid.UUID) int {
+	if limit, ok := l.customerLimits[customerID]; ok {
+		return limit
+	}
+	return l.limit
+}
+
+// AddTag tags order in any status, adding tag the order already has changes nothing
+func (o *orderService) AddTag(orderID uuid.UUID, tag string) (err error) {
+	defer o.finishCommand("AddTag", orderID, &err)
+	if err = o.beforeCommand("AddTag", orderID); err != nil {
+		return err
+	}
+
+	tag, err = model.NormalizeTag(tag)
+	if err != nil {
+		return err
+	}
+
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+	if err = o.allowCustomer(order.CustomerID); err != nil {
+		return err
+	}
+
+	i, found := slices.BinarySearch(order.Tags, tag)
+	if found {
+		return nil
+	}
+	if o.options.tagLimits != nil {
+		limit := o.options.tagLimits.limitFor(order.CustomerID)
+		if limit > 0 && len(order.Tags) >= limit {
+			return ErrTagsLimitExceeded
+		}
+	}
+
+	order.Tags = slices.Insert(order.Tags, i, tag)
+	order.UpdatedAt = o.options.clock.Now()
+
+	if err = o.repo.Store(order); err != nil {
+		return err
+	}
+
+	return o.dispatcher.Dispatch(model.OrderTagsChanged{
+		OrderID:   orderID,
+		AddedTags: []string{tag},
+	})
+}
+
+func (o *orderService) RemoveTag(orderID uuid.UUID, tag string) (err error) {
+	defer o.finishCommand("RemoveTag", orderID, &err)
+	if err = o.beforeCommand("RemoveTag", orderID); err != nil {
+		return err
+	}
+
+	tag, err = model.NormalizeTag(tag)
+	if err != nil {
+		return err
+	}
+
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+	if err = o.allowCustomer(order.CustomerID); err != nil {
+		return err
+	}
+
+	i, found := slices.BinarySearch(order.Tags, tag)
+	if !found {
+		return ErrTagNotFound
+	}
+
+	order.Tags = slices.Delete(order.Tags, i, i+1)
+	order.UpdatedAt = o.options.clock.Now()
+
+	if err = o.repo.Store(order); err != nil {
+		return err
+	}
+
+	return o.dispatcher.Dispatch(model.OrderTagsChanged{
+		OrderID:     orderID,
+		RemovedTags: []string{tag},
+	})
+}
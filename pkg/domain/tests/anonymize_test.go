@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type mockErasureAuditLog struct {
+	records []service.ErasureRecord
+}
+
+func (m *mockErasureAuditLog) Record(record service.ErasureRecord) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+// onLockLocker runs onLock before the first lock, like a concurrent write landing before the lock is taken
+type onLockLocker struct {
+	mockLocker
+	onLock func()
+}
+
+func (l *onLockLocker) Lock(key string) (func() error, error) {
+	if l.onLock != nil {
+		l.onLock()
+		l.onLock = nil
+	}
+	return l.mockLocker.Lock(key)
+}
+
+func TestCustomerAnonymizer(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+	clock := modeltest.NewFakeClock(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	newLocker := func() *mockLocker {
+		return &mockLocker{held: make(map[string]bool)}
+	}
+
+	t.Run("should relink orders of the customer, keep items and drop tags", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		locker := newLocker()
+		customerOrders := []*model.Order{
+			modeltest.NewOrderBuilder().WithCustomerID(customerID).WithItems(2).WithTags("call-john-doe").Build(),
+			modeltest.NewOrderBuilder().WithCustomerID(customerID).WithStatus(model.Paid).Build(),
+		}
+		otherOrder := modeltest.NewOrderBuilder().Build()
+		require.NoError(t, repo.StoreMany(append([]*model.Order{otherOrder}, customerOrders...)))
+		dispatcher := modeltest.NewFakeEventDispatcher()
+		audit := &mockErasureAuditLog{}
+
+		stats := newMockCustomerStatsRepository()
+		require.NoError(t, stats.RecordOrder(customerID, clock.Now()))
+		projection := service.NewCustomerStatsProjection(repo, stats)
+
+		count, err := service.NewCustomerAnonymizer(repo, dispatcher, locker, audit, clock, projection).AnonymizeCustomer(customerID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		var pseudonym uuid.UUID
+		for _, order := range customerOrders {
+			stored, err := repo.Find(order.ID)
+			require.NoError(t, err)
+			require.NotEqual(t, customerID, stored.CustomerID)
+			require.NotEqual(t, uuid.Nil, stored.CustomerID)
+			require.Equal(t, order.Items, stored.Items)
+			require.Empty(t, stored.Tags)
+			if pseudonym == uuid.Nil {
+				pseudonym = stored.CustomerID
+			}
+			require.Equal(t, pseudonym, stored.CustomerID)
+		}
+		stored, err := repo.Find(otherOrder.ID)
+		require.NoError(t, err)
+		require.Equal(t, otherOrder.CustomerID, stored.CustomerID)
+
+		require.ElementsMatch(t, []service.Event{
+			model.OrderAnonymized{OrderID: customerOrders[0].ID},
+			model.OrderAnonymized{OrderID: customerOrders[1].ID},
+		}, dispatcher.Events())
+		require.Len(t, audit.records, 1)
+		require.ElementsMatch(t, []uuid.UUID{customerOrders[0].ID, customerOrders[1].ID}, audit.records[0].OrderIDs)
+		require.Equal(t, clock.Now(), audit.records[0].ErasedAt)
+
+		require.ElementsMatch(t, []string{"order:" + customerOrders[0].ID.String(), "order:" + customerOrders[1].ID.String()}, locker.locked)
+		require.Empty(t, locker.held)
+
+		_, err = stats.Find(customerID)
+		require.ErrorIs(t, err, model.ErrCustomerStatsNotFound)
+		relinked, err := stats.Find(pseudonym)
+		require.NoError(t, err)
+		require.Equal(t, 1, relinked.OrderCount)
+	})
+
+	t.Run("should not overwrite orders changed after they were streamed", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		changed := modeltest.NewOrderBuilder().WithCustomerID(customerID).Build()
+		deleted := modeltest.NewOrderBuilder().WithCustomerID(customerID).Build()
+		require.NoError(t, repo.StoreMany([]*model.Order{changed, deleted}))
+		locker := &onLockLocker{mockLocker: mockLocker{held: make(map[string]bool)}, onLock: func() {
+			order, err := repo.Find(changed.ID)
+			require.NoError(t, err)
+			order.Status = model.Paid
+			require.NoError(t, repo.Store(order))
+			require.NoError(t, repo.Delete(deleted.ID))
+		}}
+		audit := &mockErasureAuditLog{}
+
+		count, err := service.NewCustomerAnonymizer(repo, modeltest.NewFakeEventDispatcher(), locker, audit, clock).
+			AnonymizeCustomer(customerID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		stored, err := repo.Find(changed.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.Paid, stored.Status)
+		require.NotEqual(t, customerID, stored.CustomerID)
+		require.Equal(t, []uuid.UUID{changed.ID}, audit.records[0].OrderIDs)
+	})
+
+	t.Run("should anonymize orders left by failed call on retry", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		order := modeltest.NewOrderBuilder().WithCustomerID(customerID).Build()
+		require.NoError(t, repo.Store(order))
+		audit := &mockErasureAuditLog{}
+		anonymizer := service.NewCustomerAnonymizer(repo, modeltest.NewFakeEventDispatcher(), newLocker(), audit, clock)
+
+		repo.FailNext("StoreMany", errInjected)
+		_, err := anonymizer.AnonymizeCustomer(customerID)
+		require.ErrorIs(t, err, errInjected)
+
+		count, err := anonymizer.AnonymizeCustomer(customerID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		stored, err := repo.Find(order.ID)
+		require.NoError(t, err)
+		require.NotEqual(t, customerID, stored.CustomerID)
+		require.Len(t, audit.records, 2)
+	})
+
+	t.Run("should report lost events of anonymized orders", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		require.NoError(t, repo.Store(modeltest.NewOrderBuilder().WithCustomerID(customerID).Build()))
+		dispatcher := modeltest.NewFakeEventDispatcher()
+		dispatcher.FailNext("Dispatch", errInjected)
+		audit := &mockErasureAuditLog{}
+
+		count, err := service.NewCustomerAnonymizer(repo, dispatcher, newLocker(), audit, clock).AnonymizeCustomer(customerID)
+		require.ErrorIs(t, err, service.ErrAnonymizedEventsNotDispatched)
+		require.Equal(t, 1, count)
+		require.Len(t, audit.records, 1)
+	})
+
+	t.Run("should reject nil customer ID", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		require.NoError(t, repo.Store(modeltest.NewOrderBuilder().Build()))
+
+		_, err := service.NewCustomerAnonymizer(repo, modeltest.NewFakeEventDispatcher(), newLocker(), &mockErasureAuditLog{}, clock).
+			AnonymizeCustomer(uuid.Nil)
+		require.ErrorIs(t, err, service.ErrInvalidCustomerID)
+	})
+}
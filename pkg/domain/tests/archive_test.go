@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type mockArchiveRepository struct {
+	sync.Mutex
+	store map[uuid.UUID]*model.Order
+}
+
+func (m *mockArchiveRepository) Store(order *model.Order) error {
+	m.Lock()
+	defer m.Unlock()
+	m.store[order.ID] = order
+	return nil
+}
+
+func (m *mockArchiveRepository) Find(id uuid.UUID) (*model.Order, error) {
+	m.Lock()
+	defer m.Unlock()
+	order, ok := m.store[id]
+	if !ok {
+		return nil, model.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func (m *mockArchiveRepository) Delete(id uuid.UUID) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.store, id)
+	return nil
+}
+
+// paidIsFinal lets orders leave only Open and Pending
+var paidIsFinal = service.TransitionTable{
+	model.Open:    {model.Pending, model.Paid, model.Cancelled},
+	model.Pending: {model.Paid, model.Cancelled},
+}
+
+// customerTransitions hides the table behind a custom Transitions implementation
+type customerTransitions service.TransitionTable
+
+func (c customerTransitions) For(uuid.UUID) service.TransitionTable {
+	return service.TransitionTable(c)
+}
+
+func TestOrderArchiver(t *testing.T) {
+	setup := func(t *testing.T) (*modeltest.FakeOrderRepository, *mockArchiveRepository, uuid.UUID, uuid.UUID) {
+		repo := modeltest.NewFakeOrderRepository()
+		archive := &mockArchiveRepository{store: make(map[uuid.UUID]*model.Order)}
+		old := time.Now().UTC().AddDate(0, -7, 0)
+
+		paid := modeltest.NewOrderBuilder().WithStatus(model.Paid).WithItems(2).WithUpdatedAt(old).Build()
+		open := modeltest.NewOrderBuilder().WithUpdatedAt(old).Build()
+		require.NoError(t, repo.Store(paid))
+		require.NoError(t, repo.Store(open))
+		require.NoError(t, repo.Store(modeltest.NewOrderBuilder().WithStatus(model.Cancelled).Build()))
+		return repo, archive, paid.ID, open.ID
+	}
+
+	t.Run("should move old terminal orders to archive", func(t *testing.T) {
+		repo, archive, paidID, openID := setup(t)
+		archiver := service.NewOrderArchiver(repo, archive, paidIsFinal)
+
+		archived, err := archiver.Archive(180 * 24 * time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 1, archived)
+
+		_, err = repo.Find(paidID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		_, err = repo.Find(openID)
+		require.NoError(t, err)
+
+		readThrough := service.NewArchiveReadThroughRepository(repo, archive)
+		order, err := readThrough.Find(paidID)
+		require.NoError(t, err)
+		require.Equal(t, paidID, order.ID)
+
+		orders, err := readThrough.FindMany([]uuid.UUID{paidID, openID, uuid.Must(uuid.NewV7())})
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+	})
+
+	t.Run("should restore archived order", func(t *testing.T) {
+		repo, archive, paidID, _ := setup(t)
+		archiver := service.NewOrderArchiver(repo, archive, paidIsFinal)
+		_, err := archiver.Archive(180 * 24 * time.Hour)
+		require.NoError(t, err)
+
+		require.NoError(t, archiver.Restore(paidID))
+
+		_, err = repo.Find(paidID)
+		require.NoError(t, err)
+		_, err = archive.Find(paidID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+	})
+
+	t.Run("should not archive orders which can still change status", func(t *testing.T) {
+		repo, archive, paidID, _ := setup(t)
+		archiver := service.NewOrderArchiver(repo, archive, service.DefaultTransitionTable())
+
+		archived, err := archiver.Archive(180 * 24 * time.Hour)
+		require.NoError(t, err)
+		require.Zero(t, archived)
+		_, err = repo.Find(paidID)
+		require.NoError(t, err)
+	})
+
+	t.Run("should archive by transition table of the order customer", func(t *testing.T) {
+		repo, archive, paidID, _ := setup(t)
+		paid, err := repo.Find(paidID)
+		require.NoError(t, err)
+		old := time.Now().UTC().AddDate(0, -7, 0)
+		otherPaid := modeltest.NewOrderBuilder().WithStatus(model.Paid).WithUpdatedAt(old).Build()
+		require.NoError(t, repo.Store(otherPaid))
+		transitions := service.NewGatedTransitions(service.DefaultTransitionTable(), customerFlags{paid.CustomerID: true},
+			service.TransitionGate{Flag: "paid_is_final", Table: paidIsFinal},
+		)
+
+		archived, err := service.NewOrderArchiver(repo, archive, transitions).Archive(180 * 24 * time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 1, archived)
+		_, err = archive.Find(paidID)
+		require.NoError(t, err)
+		_, err = repo.Find(otherPaid.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("should check every status for custom transitions", func(t *testing.T) {
+		repo, archive, paidID, _ := setup(t)
+
+		archived, err := service.NewOrderArchiver(repo, archive, customerTransitions(paidIsFinal)).Archive(180 * 24 * time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 1, archived)
+		_, err = archive.Find(paidID)
+		require.NoError(t, err)
+	})
+
+	t.Run("should delete and purge archived orders through read-through repository", func(t *testing.T) {
+		repo, archive, paidID, _ := setup(t)
+		_, err := service.NewOrderArchiver(repo, archive, paidIsFinal).Archive(180 * 24 * time.Hour)
+		require.NoError(t, err)
+		readThrough := service.NewArchiveReadThroughRepository(repo, archive)
+
+		require.NoError(t, readThrough.Delete(paidID))
+		_, err = readThrough.Find(paidID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		_, err = archive.Find(paidID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+
+		cancelled := modeltest.NewOrderBuilder().WithStatus(model.Cancelled).Build()
+		require.NoError(t, archive.Store(cancelled))
+		require.NoError(t, readThrough.Purge(cancelled.ID))
+		_, err = archive.Find(cancelled.ID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		require.ErrorIs(t, readThrough.Purge(cancelled.ID), model.ErrOrderNotFound)
+	})
+}
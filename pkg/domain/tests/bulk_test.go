@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+// failAtDispatcher fails only the dispatch number failAt, counting from one
+type failAtDispatcher struct {
+	dispatched int
+	failAt     int
+}
+
+func (d *failAtDispatcher) Dispatch(service.Event) error {
+	d.dispatched++
+	if d.dispatched == d.failAt {
+		return errInjected
+	}
+	return nil
+}
+
+func TestSetStatusBulk(t *testing.T) {
+	setup := func(t *testing.T) (service.Order, *modeltest.FakeOrderRepository, *modeltest.FakeEventDispatcher) {
+		repo := modeltest.NewFakeOrderRepository()
+		dispatcher := modeltest.NewFakeEventDispatcher()
+		return service.NewOrderService(repo, dispatcher), repo, dispatcher
+	}
+	customerID := uuid.Must(uuid.NewV7())
+
+	t.Run("should set status and report per-order results", func(t *testing.T) {
+		orderSvc, repo, dispatcher := setup(t)
+		firstID, _ := orderSvc.CreateOrder(customerID)
+		cancelledID, _ := orderSvc.CreateOrder(customerID)
+		require.NoError(t, orderSvc.SetStatus(cancelledID, model.Cancelled))
+		missingID := uuid.Must(uuid.NewV7())
+		dispatcher.Clear()
+
+		results, err := orderSvc.SetStatusBulk([]uuid.UUID{firstID, cancelledID, missingID}, model.Paid)
+		require.NoError(t, err)
+		require.Equal(t, []service.BulkStatusResult{
+			{OrderID: firstID},
+			{OrderID: cancelledID, Err: service.ErrInvalidOrderStatus},
+			{OrderID: missingID, Err: model.ErrOrderNotFound},
+		}, results)
+
+		order, _ := repo.Find(firstID)
+		require.Equal(t, model.Paid, order.Status)
+		require.Equal(t, []service.Event{modeltest.StatusChanged(order, model.Paid)}, dispatcher.Events())
+	})
+
+	t.Run("should dispatch a single consolidated event", func(t *testing.T) {
+		orderSvc, _, dispatcher := setup(t)
+		orderIDs := make([]uuid.UUID, 0, 250)
+		for range 250 {
+			orderID, _ := orderSvc.CreateOrder(customerID)
+			orderIDs = append(orderIDs, orderID)
+		}
+		dispatcher.Clear()
+
+		results, err := orderSvc.SetStatusBulk(orderIDs, model.Pending, service.WithConsolidatedEvent())
+		require.NoError(t, err)
+		require.Len(t, results, 250)
+
+		events := dispatcher.Events()
+		require.Len(t, events, 1)
+		require.Equal(t, model.OrderStatusBulkChanged{OrderIDs: orderIDs, NewStatus: model.Pending}, events[0])
+	})
+
+	t.Run("should transition repeated order once", func(t *testing.T) {
+		orderSvc, _, dispatcher := setup(t)
+		orderID, _ := orderSvc.CreateOrder(customerID)
+		dispatcher.Clear()
+
+		results, err := orderSvc.SetStatusBulk([]uuid.UUID{orderID, orderID}, model.Pending)
+		require.NoError(t, err)
+		require.Equal(t, []service.BulkStatusResult{{OrderID: orderID}}, results)
+		require.Len(t, dispatcher.Events(), 1)
+	})
+
+	t.Run("should report orders not attempted after failed batch", func(t *testing.T) {
+		orderSvc, repo, _ := setup(t)
+		orderIDs := make([]uuid.UUID, 0, 150)
+		for range 150 {
+			orderID, _ := orderSvc.CreateOrder(customerID)
+			orderIDs = append(orderIDs, orderID)
+		}
+		repo.FailNext("StoreMany", errInjected)
+
+		results, err := orderSvc.SetStatusBulk(orderIDs, model.Pending)
+		require.ErrorIs(t, err, errInjected)
+		require.Len(t, results, 150)
+		require.ErrorIs(t, results[0].Err, errInjected)
+		require.Equal(t, service.BulkStatusResult{OrderID: orderIDs[149], Err: service.ErrBulkNotAttempted}, results[149])
+	})
+
+	t.Run("should report orders whose events were not dispatched", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		dispatcher := &failAtDispatcher{}
+		orderSvc := service.NewOrderService(repo, dispatcher)
+		orderIDs := make([]uuid.UUID, 0, 3)
+		for range 3 {
+			orderID, _ := orderSvc.CreateOrder(customerID)
+			orderIDs = append(orderIDs, orderID)
+		}
+		dispatcher.dispatched, dispatcher.failAt = 0, 2
+
+		results, err := orderSvc.SetStatusBulk(orderIDs, model.Pending)
+		require.ErrorIs(t, err, errInjected)
+		require.Equal(t, []service.BulkStatusResult{
+			{OrderID: orderIDs[0]},
+			{OrderID: orderIDs[1], Err: errInjected},
+			{OrderID: orderIDs[2]},
+		}, results)
+		require.Equal(t, 3, dispatcher.dispatched)
+		for _, orderID := range orderIDs {
+			order, err := repo.Find(orderID)
+			require.NoError(t, err)
+			require.Equal(t, model.Pending, order.Status)
+		}
+	})
+
+	t.Run("should report every changed order when consolidated event is not dispatched", func(t *testing.T) {
+		orderSvc, _, dispatcher := setup(t)
+		orderIDs := make([]uuid.UUID, 0, 2)
+		for range 2 {
+			orderID, _ := orderSvc.CreateOrder(customerID)
+			orderIDs = append(orderIDs, orderID)
+		}
+		dispatcher.FailNext("Dispatch", errInjected)
+
+		results, err := orderSvc.SetStatusBulk(append(orderIDs, uuid.Must(uuid.NewV7())), model.Pending, service.WithConsolidatedEvent())
+		require.ErrorIs(t, err, errInjected)
+		require.ErrorIs(t, results[0].Err, errInjected)
+		require.ErrorIs(t, results[1].Err, errInjected)
+		require.ErrorIs(t, results[2].Err, model.ErrOrderNotFound)
+	})
+}
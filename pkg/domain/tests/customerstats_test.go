@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type mockCustomerStatsRepository struct {
+	sync.Mutex
+	store      map[uuid.UUID]*model.CustomerStats
+	orderSpend map[uuid.UUID]float64
+}
+
+func newMockCustomerStatsRepository() *mockCustomerStatsRepository {
+	return &mockCustomerStatsRepository{
+		store:      make(map[uuid.UUID]*model.CustomerStats),
+		orderSpend: make(map[uuid.UUID]float64),
+	}
+}
+
+func (m *mockCustomerStatsRepository) get(customerID uuid.UUID) *model.CustomerStats {
+	stats, ok := m.store[customerID]
+	if !ok {
+		stats = &model.CustomerStats{CustomerID: customerID}
+		m.store[customerID] = stats
+	}
+	return stats
+}
+
+func (m *mockCustomerStatsRepository) RecordOrder(customerID uuid.UUID, orderedAt time.Time) error {
+	m.Lock()
+	defer m.Unlock()
+	stats := m.get(customerID)
+	stats.OrderCount++
+	if stats.LastOrderAt == nil || orderedAt.After(*stats.LastOrderAt) {
+		stats.LastOrderAt = &orderedAt
+	}
+	return nil
+}
+
+func (m *mockCustomerStatsRepository) SetOrderSpend(customerID, orderID uuid.UUID, amount float64) error {
+	m.Lock()
+	defer m.Unlock()
+	m.get(customerID).TotalSpend += amount - m.orderSpend[orderID]
+	m.orderSpend[orderID] = amount
+	return nil
+}
+
+func (m *mockCustomerStatsRepository) Relink(customerID, pseudonym uuid.UUID) error {
+	m.Lock()
+	defer m.Unlock()
+	stats, ok := m.store[customerID]
+	if !ok {
+		return nil
+	}
+	delete(m.store, customerID)
+	merged := m.get(pseudonym)
+	merged.OrderCount += stats.OrderCount
+	merged.TotalSpend += stats.TotalSpend
+	if merged.LastOrderAt == nil || stats.LastOrderAt != nil && stats.LastOrderAt.After(*merged.LastOrderAt) {
+		merged.LastOrderAt = stats.LastOrderAt
+	}
+	return nil
+}
+
+func (m *mockCustomerStatsRepository) Find(customerID uuid.UUID) (*model.CustomerStats, error) {
+	m.Lock()
+	defer m.Unlock()
+	stats, ok := m.store[customerID]
+	if !ok {
+		return nil, model.ErrCustomerStatsNotFound
+	}
+	statsCopy := *stats
+	return &statsCopy, nil
+}
+
+func TestCustomerStatsProjection(t *testing.T) {
+	t.Run("should maintain order count, total spend and last order date", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		projection := service.NewCustomerStatsProjection(repo, newMockCustomerStatsRepository())
+		orderSvc := service.NewOrderService(repo, projection)
+		customerID := uuid.Must(uuid.NewV7())
+
+		firstID, err := orderSvc.CreateOrder(customerID)
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(firstID, uuid.Must(uuid.NewV7()), 100)
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(firstID, uuid.Must(uuid.NewV7()), 50)
+		require.NoError(t, err)
+		require.NoError(t, orderSvc.SetStatus(firstID, model.Paid))
+
+		secondID, err := orderSvc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		stats, err := projection.GetCustomerStats(customerID)
+		require.NoError(t, err)
+		require.Equal(t, 2, stats.OrderCount)
+		require.InDelta(t, 150, stats.TotalSpend, 0.001)
+
+		second, _ := repo.Find(secondID)
+		require.Equal(t, second.CreatedAt, *stats.LastOrderAt)
+	})
+
+	t.Run("should return empty stats for unknown customer", func(t *testing.T) {
+		projection := service.NewCustomerStatsProjection(modeltest.NewFakeOrderRepository(), newMockCustomerStatsRepository())
+		customerID := uuid.Must(uuid.NewV7())
+
+		stats, err := projection.GetCustomerStats(customerID)
+		require.NoError(t, err)
+		require.Equal(t, &model.CustomerStats{CustomerID: customerID}, stats)
+	})
+
+	t.Run("should count spend of an order once and drop it when order leaves paid", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		projection := service.NewCustomerStatsProjection(repo, newMockCustomerStatsRepository())
+		orderSvc := service.NewOrderService(repo, projection)
+		customerID := uuid.Must(uuid.NewV7())
+
+		orderID, err := orderSvc.CreateOrder(customerID)
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 100)
+		require.NoError(t, err)
+		for _, status := range []model.OrderStatus{model.Paid, model.Paid, model.Pending, model.Paid} {
+			require.NoError(t, orderSvc.SetStatus(orderID, status))
+		}
+
+		stats, err := projection.GetCustomerStats(customerID)
+		require.NoError(t, err)
+		require.InDelta(t, 100, stats.TotalSpend, 0.001)
+
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Cancelled))
+		stats, err = projection.GetCustomerStats(customerID)
+		require.NoError(t, err)
+		require.InDelta(t, 0, stats.TotalSpend, 0.001)
+	})
+}
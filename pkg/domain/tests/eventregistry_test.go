@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type customEvent struct {
+	Note string
+}
+
+func (e customEvent) Type() string {
+	return "CustomEvent"
+}
+
+func TestEventRegistry(t *testing.T) {
+	t.Run("should decode built-in events", func(t *testing.T) {
+		events := []service.Event{
+			model.OrderCreated{OrderID: uuid.Must(uuid.NewV7()), CustomerID: uuid.Must(uuid.NewV7())},
+			model.OrderItemsChanged{OrderID: uuid.Must(uuid.NewV7()), AddedItems: []uuid.UUID{uuid.Must(uuid.NewV7())}},
+			model.OrderStatusChanged{OrderID: uuid.Must(uuid.NewV7()), NewStatus: model.Paid},
+			model.OrderStatusBulkChanged{OrderIDs: []uuid.UUID{uuid.Must(uuid.NewV7())}, NewStatus: model.Cancelled},
+			model.OrderDeleted{OrderID: uuid.Must(uuid.NewV7())},
+			model.OrderAnonymized{OrderID: uuid.Must(uuid.NewV7())},
+		}
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			require.NoError(t, err)
+
+			decoded, err := service.DecodeEvent(event.Type(), data)
+			require.NoError(t, err)
+			require.Equal(t, event, decoded)
+		}
+	})
+
+	t.Run("should decode registered events", func(t *testing.T) {
+		service.RegisterEvent[customEvent](customEvent{}.Type())
+
+		decoded, err := service.DecodeEvent("CustomEvent", []byte(`{"Note":"hello"}`))
+		require.NoError(t, err)
+		require.Equal(t, customEvent{Note: "hello"}, decoded)
+	})
+
+	t.Run("should reject unknown and malformed events", func(t *testing.T) {
+		_, err := service.DecodeEvent("Unknown", []byte(`{}`))
+		require.ErrorIs(t, err, service.ErrUnknownEventType)
+
+		_, err = service.DecodeEvent(model.OrderCreated{}.Type(), []byte(`{`))
+		require.Error(t, err)
+		require.NotErrorIs(t, err, service.ErrUnknownEventType)
+	})
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var errInjected = errors.New("injected error")
+
+func TestFakes(t *testing.T) {
+	t.Run("should fail only the next call", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		dispatcher := modeltest.NewFakeEventDispatcher()
+		orderSvc := service.NewOrderService(repo, dispatcher)
+
+		repo.FailNext("Store", errInjected)
+		_, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, errInjected)
+		require.Empty(t, dispatcher.Events())
+
+		_, err = orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.Equal(t, 2, repo.CallCount("Store"))
+		require.Len(t, dispatcher.Events(), 1)
+	})
+
+	t.Run("should fail every call until reset", func(t *testing.T) {
+		dispatcher := modeltest.NewFakeEventDispatcher()
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), dispatcher)
+
+		dispatcher.FailOn("Dispatch", errInjected)
+		for range 2 {
+			_, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+			require.ErrorIs(t, err, errInjected)
+		}
+
+		dispatcher.Reset()
+		_, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+	})
+
+	t.Run("should not share stored orders with callers", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		order := modeltest.NewOrderBuilder().WithItems(1).Build()
+		require.NoError(t, repo.Store(order))
+
+		order.Items = nil
+		found, err := repo.Find(order.ID)
+		require.NoError(t, err)
+		require.Len(t, found.Items, 1)
+	})
+
+	t.Run("should keep failing without recording calls", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		orderSvc := service.NewOrderService(repo, modeltest.NewFakeEventDispatcher())
+		_, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+
+		repo.StopRecording()
+		repo.FailNext("Store", errInjected)
+		_, err = orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, errInjected)
+		_, err = orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.Empty(t, repo.Calls())
+	})
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type customerFlags map[uuid.UUID]bool
+
+func (f customerFlags) Enabled(_ string, customerID uuid.UUID) bool {
+	return f[customerID]
+}
+
+func TestFeatureFlags(t *testing.T) {
+	enabledCustomer := uuid.Must(uuid.NewV7())
+	otherCustomer := uuid.Must(uuid.NewV7())
+
+	flags := customerFlags{enabledCustomer: true}
+	orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher(),
+		service.WithFeatureFlags(flags),
+		service.WithTransitions(service.NewGatedTransitions(service.DefaultTransitionTable(), flags, service.TransitionGate{
+			Flag:  "strict_transitions",
+			Table: service.TransitionTable{model.Open: {model.Pending}},
+		})),
+		service.WithGatedItemValidators("price_validation", func(_ *model.Order, item model.Item) error {
+			if item.Price <= 0 {
+				return errInvalidPrice
+			}
+			return nil
+		}),
+	)
+
+	t.Run("should use gated transition table for enabled customers", func(t *testing.T) {
+		gatedOrderID, err := orderSvc.CreateOrder(enabledCustomer)
+		require.NoError(t, err)
+		otherOrderID, err := orderSvc.CreateOrder(otherCustomer)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, orderSvc.SetStatus(gatedOrderID, model.Cancelled), service.ErrInvalidOrderStatus)
+		require.NoError(t, orderSvc.SetStatus(otherOrderID, model.Cancelled))
+	})
+
+	t.Run("should run gated validators for enabled customers", func(t *testing.T) {
+		gatedOrderID, err := orderSvc.CreateOrder(enabledCustomer)
+		require.NoError(t, err)
+		otherOrderID, err := orderSvc.CreateOrder(otherCustomer)
+		require.NoError(t, err)
+
+		_, err = orderSvc.AddItem(gatedOrderID, uuid.Must(uuid.NewV7()), 0)
+		require.ErrorIs(t, err, errInvalidPrice)
+		_, err = orderSvc.AddItem(otherOrderID, uuid.Must(uuid.NewV7()), 0)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var errRejectedByHook = errors.New("rejected by hook")
+
+type hookCall struct {
+	command string
+	orderID uuid.UUID
+	err     error
+}
+
+type recordingHook struct {
+	reject map[uuid.UUID]bool
+	before []hookCall
+	after  []hookCall
+}
+
+func (h *recordingHook) BeforeCommand(command string, orderID uuid.UUID) error {
+	h.before = append(h.before, hookCall{command: command, orderID: orderID})
+	if h.reject[orderID] {
+		return errRejectedByHook
+	}
+	return nil
+}
+
+func (h *recordingHook) AfterCommand(command string, orderID uuid.UUID, err error) {
+	h.after = append(h.after, hookCall{command: command, orderID: orderID, err: err})
+}
+
+func TestOrderServiceHooks(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+
+	t.Run("should call hooks around commands", func(t *testing.T) {
+		hook := &recordingHook{}
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher(),
+			service.WithHooks(hook),
+		)
+
+		orderID, err := orderSvc.CreateOrder(customerID)
+		require.NoError(t, err)
+		err = orderSvc.DeleteItem(orderID, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, service.ErrItemNotFound)
+		_, err = orderSvc.GetOrder(orderID)
+		require.NoError(t, err)
+
+		require.Equal(t, []hookCall{
+			{command: "CreateOrder", orderID: uuid.Nil},
+			{command: "DeleteItem", orderID: orderID},
+		}, hook.before)
+		require.Len(t, hook.after, 2)
+		require.Equal(t, "CreateOrder", hook.after[0].command)
+		require.Equal(t, orderID, hook.after[0].orderID)
+		require.NoError(t, hook.after[0].err)
+		require.ErrorIs(t, hook.after[1].err, service.ErrItemNotFound)
+	})
+
+	t.Run("should reject commands", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		rejectedOrder := modeltest.NewOrderBuilder().Build()
+		acceptedOrder := modeltest.NewOrderBuilder().Build()
+		require.NoError(t, repo.StoreMany([]*model.Order{rejectedOrder, acceptedOrder}))
+		hook := &recordingHook{reject: map[uuid.UUID]bool{rejectedOrder.ID: true}}
+		dispatcher := modeltest.NewFakeEventDispatcher()
+		orderSvc := service.NewOrderService(repo, dispatcher, service.WithHooks(hook))
+
+		err := orderSvc.SetStatus(rejectedOrder.ID, model.Paid)
+		require.ErrorIs(t, err, errRejectedByHook)
+		require.Empty(t, dispatcher.Events())
+
+		results, err := orderSvc.SetStatusBulk([]uuid.UUID{rejectedOrder.ID, acceptedOrder.ID}, model.Paid)
+		require.NoError(t, err)
+		require.ErrorIs(t, results[0].Err, errRejectedByHook)
+		require.NoError(t, results[1].Err)
+
+		stored, err := repo.Find(rejectedOrder.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.Open, stored.Status)
+		require.Len(t, hook.after, 3)
+		require.ErrorIs(t, hook.after[0].err, errRejectedByHook)
+	})
+}
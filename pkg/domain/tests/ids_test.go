@@ -0,0 +1,32 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+)
+
+func TestSequentialIDGenerator(t *testing.T) {
+	t.Run("should generate increasing UUIDv7", func(t *testing.T) {
+		start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		generator := modeltest.NewSequentialIDGenerator(start)
+
+		var previous uuid.UUID
+		for range 300 {
+			id, err := generator.NextID()
+			require.NoError(t, err)
+			require.Equal(t, uuid.Version(7), id.Version())
+			require.Equal(t, uuid.RFC4122, id.Variant())
+			require.Positive(t, bytes.Compare(id[:], previous[:]))
+
+			sec, nsec := id.Time().UnixTime()
+			require.Equal(t, start, time.Unix(sec, nsec).UTC())
+			previous = id
+		}
+	})
+}
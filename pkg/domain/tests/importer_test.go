@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+func TestOrderImporter(t *testing.T) {
+	t.Run("should store all orders in batches and dispatch events", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		dispatcher := modeltest.NewFakeEventDispatcher()
+		importer := service.NewOrderImporter(repo, dispatcher, 2)
+
+		existingID := uuid.Must(uuid.NewV7())
+		orders := []*model.Order{
+			{ID: existingID, CustomerID: uuid.Must(uuid.NewV7()), Status: model.Paid},
+			{CustomerID: uuid.Must(uuid.NewV7())},
+			{CustomerID: uuid.Must(uuid.NewV7())},
+		}
+
+		imported, err := importer.Import(slices.Values(orders))
+		require.NoError(t, err)
+		require.Equal(t, 3, imported)
+
+		stored, err := repo.Find(existingID)
+		require.NoError(t, err)
+		require.Equal(t, model.Paid, stored.Status)
+		for _, order := range orders {
+			require.NotEqual(t, uuid.Nil, order.ID)
+			require.False(t, order.CreatedAt.IsZero())
+		}
+
+		events := dispatcher.Events()
+		require.Len(t, events, 3)
+		require.Equal(t, model.OrderCreated{OrderID: existingID, CustomerID: orders[0].CustomerID}, events[0])
+	})
+
+	t.Run("should count stored batch when dispatch fails", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		dispatcher := modeltest.NewFakeEventDispatcher()
+		dispatcher.FailNext("Dispatch", errInjected)
+		importer := service.NewOrderImporter(repo, dispatcher, 2)
+
+		orders := []*model.Order{
+			{CustomerID: uuid.Must(uuid.NewV7())},
+			{CustomerID: uuid.Must(uuid.NewV7())},
+			{CustomerID: uuid.Must(uuid.NewV7())},
+		}
+
+		imported, err := importer.Import(slices.Values(orders))
+		require.ErrorIs(t, err, service.ErrImportEventsNotDispatched)
+		require.ErrorIs(t, err, errInjected)
+		require.Equal(t, 2, imported)
+		require.Len(t, dispatcher.Events(), 1)
+	})
+
+	t.Run("should reject nil order", func(t *testing.T) {
+		importer := service.NewOrderImporter(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher(), 2)
+
+		imported, err := importer.Import(slices.Values([]*model.Order{nil}))
+		require.ErrorIs(t, err, service.ErrNilImportOrder)
+		require.Zero(t, imported)
+	})
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func TestJSONMarshaling(t *testing.T) {
+	orderID := uuid.MustParse("01900000-0000-7000-8000-000000000001")
+	customerID := uuid.MustParse("01900000-0000-7000-8000-000000000002")
+	itemID := uuid.MustParse("01900000-0000-7000-8000-000000000003")
+	productID := uuid.MustParse("01900000-0000-7000-8000-000000000004")
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	deletedAt := createdAt.Add(time.Hour)
+
+	cases := map[string]any{
+		"order": model.Order{
+			ID:         orderID,
+			CustomerID: customerID,
+			Status:     model.Pending,
+			Items:      []model.Item{{ID: itemID, ProductID: productID, Price: 9.99}},
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt.Add(time.Minute),
+		},
+		"order_deleted": model.Order{
+			ID:         orderID,
+			CustomerID: customerID,
+			Status:     model.Cancelled,
+			Items:      []model.Item{},
+			CreatedAt:  createdAt,
+			UpdatedAt:  deletedAt,
+			DeletedAt:  &deletedAt,
+		},
+		"order_item_adjustments": model.Order{
+			ID:         orderID,
+			CustomerID: customerID,
+			Status:     model.Open,
+			Items: []model.Item{{
+				ID:          itemID,
+				ProductID:   productID,
+				Price:       9,
+				Adjustments: []model.PriceAdjustment{{Reason: "promo", Amount: -0.99}},
+			}},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+		"customer_stats": model.CustomerStats{
+			CustomerID:  customerID,
+			OrderCount:  3,
+			TotalSpend:  29.97,
+			LastOrderAt: &createdAt,
+		},
+		"event_order_created":             model.OrderCreated{OrderID: orderID, CustomerID: customerID},
+		"event_order_items_changed":       model.OrderItemsChanged{OrderID: orderID, AddedItems: []uuid.UUID{itemID}},
+		"event_order_status_changed":      model.OrderStatusChanged{OrderID: orderID, NewStatus: model.Paid},
+		"event_order_status_bulk_changed": model.OrderStatusBulkChanged{OrderIDs: []uuid.UUID{orderID}, NewStatus: model.Open},
+		"event_order_deleted":             model.OrderDeleted{OrderID: orderID},
+		"event_order_anonymized":          model.OrderAnonymized{OrderID: orderID},
+	}
+
+	for name, value := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.MarshalIndent(value, "", "  ")
+			require.NoError(t, err)
+
+			path := filepath.Join("testdata", name+".golden.json")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(path, append(data, '\n'), 0o644))
+			}
+			golden, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.JSONEq(t, string(golden), string(data))
+		})
+	}
+}
+
+func TestOrderStatusText(t *testing.T) {
+	t.Run("should round trip all statuses", func(t *testing.T) {
+		for _, status := range []model.OrderStatus{model.Open, model.Pending, model.Paid, model.Cancelled} {
+			data, err := json.Marshal(status)
+			require.NoError(t, err)
+
+			var decoded model.OrderStatus
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			require.Equal(t, status, decoded)
+		}
+	})
+
+	t.Run("should reject unknown statuses", func(t *testing.T) {
+		_, err := json.Marshal(model.OrderStatus(42))
+		require.ErrorIs(t, err, model.ErrUnknownOrderStatus)
+
+		var decoded model.OrderStatus
+		require.ErrorIs(t, json.Unmarshal([]byte(`"shipped"`), &decoded), model.ErrUnknownOrderStatus)
+	})
+}
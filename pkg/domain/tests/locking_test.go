@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type mockLocker struct {
+	mu     sync.Mutex
+	held   map[string]bool
+	locked []string
+}
+
+func (m *mockLocker) Lock(key string) (func() error, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.held[key] {
+		panic("lock " + key + " is already held")
+	}
+	m.held[key] = true
+	m.locked = append(m.locked, key)
+
+	return func() error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.held, key)
+		return nil
+	}, nil
+}
+
+type mockMultiLocker struct {
+	mockLocker
+	lockedMany [][]string
+}
+
+func (m *mockMultiLocker) LockMany(keys []string) (func() error, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lockedMany = append(m.lockedMany, keys)
+	return func() error { return nil }, nil
+}
+
+func TestLockingMiddleware(t *testing.T) {
+	t.Run("should lock order during mutation", func(t *testing.T) {
+		locker := &mockLocker{held: make(map[string]bool)}
+		orderSvc := service.Chain(
+			service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher()),
+			service.NewLockingMiddleware(locker),
+		)
+
+		orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 10)
+		require.NoError(t, err)
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Paid))
+
+		require.Equal(t, []string{"order:" + orderID.String(), "order:" + orderID.String()}, locker.locked)
+		require.Empty(t, locker.held)
+	})
+
+	t.Run("should lock every order of bulk call once", func(t *testing.T) {
+		locker := &mockLocker{held: make(map[string]bool)}
+		orderSvc := service.Chain(
+			service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher()),
+			service.NewLockingMiddleware(locker),
+		)
+		firstID, _ := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		secondID, _ := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+
+		_, err := orderSvc.SetStatusBulk([]uuid.UUID{secondID, firstID, secondID}, model.Pending)
+		require.NoError(t, err)
+		require.Len(t, locker.locked, 2)
+		require.Empty(t, locker.held)
+	})
+
+	t.Run("should take bulk locks at once with multi locker", func(t *testing.T) {
+		locker := &mockMultiLocker{mockLocker: mockLocker{held: make(map[string]bool)}}
+		orderSvc := service.Chain(
+			service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher()),
+			service.NewLockingMiddleware(locker),
+		)
+		firstID, _ := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		secondID, _ := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+
+		_, err := orderSvc.SetStatusBulk([]uuid.UUID{secondID, firstID}, model.Pending)
+		require.NoError(t, err)
+		require.Len(t, locker.lockedMany, 1)
+		require.Len(t, locker.lockedMany[0], 2)
+		require.Empty(t, locker.locked)
+	})
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type recordingOrderService struct {
+	service.Order
+	name  string
+	calls *[]string
+}
+
+func (r *recordingOrderService) CreateOrder(customerID uuid.UUID, opts ...service.CreateOption) (uuid.UUID, error) {
+	*r.calls = append(*r.calls, r.name)
+	return r.Order.CreateOrder(customerID, opts...)
+}
+
+func recordingMiddleware(name string, calls *[]string) service.Middleware {
+	return func(next service.Order) service.Order {
+		return &recordingOrderService{Order: next, name: name, calls: calls}
+	}
+}
+
+func TestChain(t *testing.T) {
+	t.Run("should apply middlewares from the outermost to the innermost", func(t *testing.T) {
+		var calls []string
+		base := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher())
+
+		orderSvc := service.Chain(
+			base,
+			recordingMiddleware("first", &calls),
+			recordingMiddleware("second", &calls),
+		)
+
+		_, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("should return base service without middlewares", func(t *testing.T) {
+		base := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher())
+		require.Equal(t, base, service.Chain(base))
+	})
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var errInvalidPrice = errors.New("invalid price")
+
+func TestOrderServiceOptions(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+
+	t.Run("should use clock and ID generator", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		clock := modeltest.NewFakeClock(now)
+		orderSvc := service.NewOrderService(repo, modeltest.NewFakeEventDispatcher(),
+			service.WithClock(clock),
+			service.WithIDGenerator(modeltest.NewSequentialIDGenerator(now)),
+		)
+		expectedIDs := modeltest.NewSequentialIDGenerator(now)
+		orderID, _ := expectedIDs.NextID()
+		itemID, _ := expectedIDs.NextID()
+
+		createdID, err := orderSvc.CreateOrder(customerID)
+		require.NoError(t, err)
+		require.Equal(t, orderID, createdID)
+		clock.Advance(time.Minute)
+		createdItemID, err := orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 10)
+		require.NoError(t, err)
+		require.Equal(t, itemID, createdItemID)
+
+		order, _ := repo.Find(orderID)
+		require.Equal(t, now, order.CreatedAt)
+		require.Equal(t, now.Add(time.Minute), order.UpdatedAt)
+	})
+
+	t.Run("should reject items failing validation", func(t *testing.T) {
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher(),
+			service.WithItemValidators(func(_ *model.Order, item model.Item) error {
+				if item.Price <= 0 {
+					return errInvalidPrice
+				}
+				return nil
+			}),
+		)
+		orderID, _ := orderSvc.CreateOrder(customerID)
+
+		_, err := orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), -1)
+		require.ErrorIs(t, err, errInvalidPrice)
+	})
+
+	t.Run("should limit items per order", func(t *testing.T) {
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher(),
+			service.WithMaxItemsPerOrder(1),
+		)
+		orderID, _ := orderSvc.CreateOrder(customerID)
+
+		_, err := orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 10)
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 10)
+		require.ErrorIs(t, err, service.ErrItemsLimitExceeded)
+	})
+
+	t.Run("should follow transition table", func(t *testing.T) {
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher(),
+			service.WithTransitionTable(service.TransitionTable{
+				model.Open:    {model.Pending, model.Cancelled},
+				model.Pending: {model.Paid, model.Cancelled},
+			}),
+		)
+		orderID, _ := orderSvc.CreateOrder(customerID)
+
+		require.ErrorIs(t, orderSvc.SetStatus(orderID, model.Paid), service.ErrInvalidOrderStatus)
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Pending))
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Paid))
+		require.ErrorIs(t, orderSvc.SetStatus(orderID, model.Open), service.ErrInvalidOrderStatus)
+	})
+}
+
+func TestOrderServiceErrorContext(t *testing.T) {
+	orderID := uuid.Must(uuid.NewV7())
+
+	t.Run("should wrap errors with operation and order ID", func(t *testing.T) {
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher())
+
+		_, err := orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 10)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		require.EqualError(t, err, "orderService.AddItem order="+orderID.String()+": order not found")
+	})
+
+	t.Run("should capture stack traces when enabled", func(t *testing.T) {
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher(),
+			service.WithErrorStackTraces(),
+		)
+
+		err := orderSvc.SetStatus(orderID, model.Paid)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		require.Contains(t, fmt.Sprintf("%+v", err), "service.(*orderService).SetStatus")
+	})
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type nopEventDispatcher struct{}
+
+func (nopEventDispatcher) Dispatch(service.Event) error {
+	return nil
+}
+
+func BenchmarkOrderService(b *testing.B) {
+	customerID := uuid.Must(uuid.NewV7())
+	productID := uuid.Must(uuid.NewV7())
+
+	b.Run("CreateOrder", func(b *testing.B) {
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), nopEventDispatcher{})
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			if _, err := orderSvc.CreateOrder(customerID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("AddItem", func(b *testing.B) {
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), nopEventDispatcher{})
+		orderID, err := orderSvc.CreateOrder(customerID)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			if _, err := orderSvc.AddItem(orderID, productID, 100); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("AddAndDeleteItem", func(b *testing.B) {
+		orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), nopEventDispatcher{})
+		orderID, err := orderSvc.CreateOrder(customerID)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			itemID, err := orderSvc.AddItem(orderID, productID, 100)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := orderSvc.DeleteItem(orderID, itemID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkOrderImporter(b *testing.B) {
+	customerID := uuid.Must(uuid.NewV7())
+
+	for _, batchSize := range []int{1, 100, 1000} {
+		b.Run(fmt.Sprintf("batch=%d", batchSize), func(b *testing.B) {
+			importer := service.NewOrderImporter(modeltest.NewFakeOrderRepository(), nopEventDispatcher{}, batchSize)
+			orders := func(yield func(*model.Order) bool) {
+				for range b.N {
+					if !yield(&model.Order{CustomerID: customerID}) {
+						return
+					}
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			if _, err := importer.Import(orders); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
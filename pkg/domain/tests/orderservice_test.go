@@ -1,95 +1,20 @@
 package tests
 
 import (
-	"sync"
 	"testing"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
 	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
 )
 
-var _ model.OrderRepository = &mockOrderRepository{}
-
-type mockOrderRepository struct {
-	sync.RWMutex
-	store map[uuid.UUID]*model.Order
-}
-
-func newMockOrderRepository() *mockOrderRepository {
-	return &mockOrderRepository{
-		store: make(map[uuid.UUID]*model.Order),
-	}
-}
-
-func (m *mockOrderRepository) NextID() (uuid.UUID, error) {
-	return uuid.NewV7()
-}
-
-func (m *mockOrderRepository) Store(order *model.Order) error {
-	m.Lock()
-	defer m.Unlock()
-	m.store[order.ID] = order
-	return nil
-}
-
-func (m *mockOrderRepository) Find(id uuid.UUID) (*model.Order, error) {
-	m.RLock()
-	defer m.RUnlock()
-	order, ok := m.store[id]
-	if !ok || order.DeletedAt != nil {
-		return nil, model.ErrOrderNotFound
-	}
-	return order, nil
-}
-
-func (m *mockOrderRepository) Delete(id uuid.UUID) error {
-	m.Lock()
-	defer m.Unlock()
-	order, ok := m.store[id]
-	if !ok || order.DeletedAt != nil {
-		return model.ErrOrderNotFound
-	}
-	now := time.Now().UTC()
-	order.DeletedAt = &now
-	return nil
-}
-
-var _ service.EventDispatcher = &mockEventDispatcher{}
-
-type mockEventDispatcher struct {
-	sync.Mutex
-	events []service.Event
-}
-
-func (m *mockEventDispatcher) Dispatch(event service.Event) error {
-	m.Lock()
-	defer m.Unlock()
-	m.events = append(m.events, event)
-	return nil
-}
-
-func (m *mockEventDispatcher) GetEvents() []service.Event {
-	m.Lock()
-	defer m.Unlock()
-	evs := make([]service.Event, len(m.events))
-	copy(evs, m.events)
-	return evs
-}
-
-func (m *mockEventDispatcher) Clear() {
-	m.Lock()
-	defer m.Unlock()
-	m.events = nil
-}
-
 func TestOrderService(t *testing.T) {
-	setup := func(t *testing.T) (service.Order, *mockOrderRepository, *mockEventDispatcher) {
-		repo := newMockOrderRepository()
-		dispatcher := &mockEventDispatcher{}
+	setup := func(t *testing.T) (service.Order, *modeltest.FakeOrderRepository, *modeltest.FakeEventDispatcher) {
+		repo := modeltest.NewFakeOrderRepository()
+		dispatcher := modeltest.NewFakeEventDispatcher()
 		orderSvc := service.NewOrderService(repo, dispatcher)
 		return orderSvc, repo, dispatcher
 	}
@@ -110,7 +35,7 @@ func TestOrderService(t *testing.T) {
 		require.Equal(t, customerID, createdOrder.CustomerID)
 		require.Equal(t, model.Open, createdOrder.Status)
 
-		events := dispatcher.GetEvents()
+		events := dispatcher.Events()
 		require.Len(t, events, 1)
 		createdEvent, ok := events[0].(model.OrderCreated)
 		require.True(t, ok)
@@ -136,7 +61,7 @@ func TestOrderService(t *testing.T) {
 		require.Equal(t, productID, order.Items[0].ProductID)
 		require.Equal(t, price, order.Items[0].Price)
 
-		events := dispatcher.GetEvents()
+		events := dispatcher.Events()
 		require.Len(t, events, 1)
 		itemsChangedEvent, ok := events[0].(model.OrderItemsChanged)
 		require.True(t, ok)
@@ -155,7 +80,7 @@ func TestOrderService(t *testing.T) {
 
 		_, err := orderSvc.AddItem(orderID, uuid.New(), 100)
 		require.Error(t, err)
-		require.Equal(t, service.ErrInvalidOrderStatus, err)
+		require.ErrorIs(t, err, service.ErrInvalidOrderStatus)
 	})
 
 	t.Run("should delete an item from an open order", func(t *testing.T) {
@@ -171,7 +96,7 @@ func TestOrderService(t *testing.T) {
 		order, _ := repo.Find(orderID)
 		require.Empty(t, order.Items)
 
-		events := dispatcher.GetEvents()
+		events := dispatcher.Events()
 		require.Len(t, events, 1)
 		itemsChangedEvent, ok := events[0].(model.OrderItemsChanged)
 		require.True(t, ok)
@@ -199,7 +124,7 @@ func TestOrderService(t *testing.T) {
 		order, _ := repo.Find(orderID)
 		require.Equal(t, model.Paid, order.Status)
 
-		events := dispatcher.GetEvents()
+		events := dispatcher.Events()
 		require.Len(t, events, 1)
 		statusChangedEvent, ok := events[0].(model.OrderStatusChanged)
 		require.True(t, ok)
@@ -218,15 +143,29 @@ func TestOrderService(t *testing.T) {
 		_, findErr := repo.Find(orderID)
 		require.ErrorIs(t, findErr, model.ErrOrderNotFound)
 
-		repo.RLock()
-		deletedOrder := repo.store[orderID]
-		repo.RUnlock()
+		deletedOrder, ok := repo.Get(orderID)
+		require.True(t, ok)
 		require.NotNil(t, deletedOrder.DeletedAt)
 
-		events := dispatcher.GetEvents()
+		events := dispatcher.Events()
 		require.Len(t, events, 1)
 		deletedEvent, ok := events[0].(model.OrderDeleted)
 		require.True(t, ok)
 		require.Equal(t, orderID, deletedEvent.OrderID)
 	})
+
+	t.Run("should get an order through the query side", func(t *testing.T) {
+		orderSvc, _, _ := setup(t)
+		orderID, _ := orderSvc.CreateOrder(customerID)
+
+		var queries service.OrderQueries = orderSvc
+		order, err := queries.GetOrder(orderID)
+		require.NoError(t, err)
+		require.Equal(t, customerID, order.CustomerID)
+		require.Equal(t, model.Open, order.Status)
+
+		require.NoError(t, orderSvc.DeleteOrder(orderID))
+		_, err = queries.GetOrder(orderID)
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+	})
 }
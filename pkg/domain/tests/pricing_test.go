@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var errUnknownProduct = errors.New("unknown product")
+
+type tierPricing struct {
+	goldCustomers map[uuid.UUID]bool
+	unknown       uuid.UUID
+}
+
+func (p tierPricing) Price(order *model.Order, productID uuid.UUID, basePrice float64) (service.PriceQuote, error) {
+	if productID == p.unknown {
+		return service.PriceQuote{}, errUnknownProduct
+	}
+	if !p.goldCustomers[order.CustomerID] {
+		return service.PriceQuote{UnitPrice: basePrice}, nil
+	}
+	discount := basePrice * 0.1
+	return service.PriceQuote{
+		UnitPrice:   basePrice - discount,
+		Adjustments: []model.PriceAdjustment{{Reason: "gold tier", Amount: -discount}},
+	}, nil
+}
+
+func TestPricingStrategy(t *testing.T) {
+	goldCustomer := uuid.Must(uuid.NewV7())
+	unknownProduct := uuid.Must(uuid.NewV7())
+	repo := modeltest.NewFakeOrderRepository()
+	orderSvc := service.NewOrderService(repo, modeltest.NewFakeEventDispatcher(),
+		service.WithPricingStrategy(tierPricing{goldCustomers: map[uuid.UUID]bool{goldCustomer: true}, unknown: unknownProduct}),
+		service.WithItemValidators(func(_ *model.Order, item model.Item) error {
+			if item.Price > 95 {
+				return errInvalidPrice
+			}
+			return nil
+		}),
+	)
+
+	t.Run("should store priced item with adjustments", func(t *testing.T) {
+		orderID, err := orderSvc.CreateOrder(goldCustomer)
+		require.NoError(t, err)
+
+		itemID, err := orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 100)
+		require.NoError(t, err)
+
+		order, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, itemID, order.Items[0].ID)
+		require.InDelta(t, 90, order.Items[0].Price, 1e-9)
+		require.Equal(t, []model.PriceAdjustment{{Reason: "gold tier", Amount: -10}}, order.Items[0].Adjustments)
+	})
+
+	t.Run("should validate priced item and report pricing errors", func(t *testing.T) {
+		orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+
+		_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 100)
+		require.ErrorIs(t, err, errInvalidPrice)
+		_, err = orderSvc.AddItem(orderID, unknownProduct, 10)
+		require.ErrorIs(t, err, errUnknownProduct)
+	})
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+func TestOpenOrdersQuota(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+
+	setup := func() (service.Order, *modeltest.FakeOrderRepository) {
+		repo := modeltest.NewFakeOrderRepository()
+		return service.NewOrderService(repo, modeltest.NewFakeEventDispatcher(), service.WithOpenOrdersQuota(repo, 2)), repo
+	}
+
+	t.Run("should reject orders above the quota unless overridden", func(t *testing.T) {
+		orderSvc, _ := setup()
+
+		firstID, err := orderSvc.CreateOrder(customerID)
+		require.NoError(t, err)
+		_, err = orderSvc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		_, err = orderSvc.CreateOrder(customerID)
+		require.ErrorIs(t, err, model.ErrOpenOrdersLimitExceeded)
+		_, err = orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		_, err = orderSvc.CreateOrder(customerID, service.WithQuotaOverride())
+		require.NoError(t, err)
+
+		require.NoError(t, orderSvc.SetStatus(firstID, model.Cancelled))
+		_, err = orderSvc.CreateOrder(customerID)
+		require.ErrorIs(t, err, model.ErrOpenOrdersLimitExceeded)
+	})
+
+	t.Run("should not exceed the quota with concurrent calls", func(t *testing.T) {
+		orderSvc, _ := setup()
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			created int
+		)
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := orderSvc.CreateOrder(customerID); err == nil {
+					mu.Lock()
+					created++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		require.Equal(t, 2, created)
+	})
+}
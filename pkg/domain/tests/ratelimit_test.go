@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type windowKey struct {
+	key         string
+	windowStart time.Time
+}
+
+type mockRateLimitStore struct {
+	counters map[windowKey]int64
+}
+
+func (m *mockRateLimitStore) Increment(key string, windowStart time.Time, _ time.Duration) (int64, error) {
+	m.counters[windowKey{key: key, windowStart: windowStart}]++
+	return m.counters[windowKey{key: key, windowStart: windowStart}], nil
+}
+
+func TestCustomerRateLimit(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+	start := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+
+	setup := func() (service.Order, *modeltest.FakeOrderRepository, *modeltest.FakeClock) {
+		repo := modeltest.NewFakeOrderRepository()
+		clock := modeltest.NewFakeClock(start)
+		orderSvc := service.NewOrderService(repo, modeltest.NewFakeEventDispatcher(),
+			service.WithClock(clock),
+			service.WithCustomerRateLimit(&mockRateLimitStore{counters: map[windowKey]int64{}}, 3, time.Minute),
+		)
+		return orderSvc, repo, clock
+	}
+
+	t.Run("should limit mutations of one customer per window", func(t *testing.T) {
+		orderSvc, _, clock := setup()
+
+		orderID, err := orderSvc.CreateOrder(customerID)
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 10)
+		require.NoError(t, err)
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Pending))
+
+		err = orderSvc.SetStatus(orderID, model.Paid)
+		require.ErrorIs(t, err, service.ErrRateLimited)
+		_, err = orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+
+		clock.Advance(time.Minute)
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Paid))
+	})
+
+	t.Run("should report limited orders in bulk results", func(t *testing.T) {
+		orderSvc, repo, _ := setup()
+		var orderIDs []uuid.UUID
+		for range 4 {
+			order := modeltest.NewOrderBuilder().WithCustomerID(customerID).Build()
+			require.NoError(t, repo.Store(order))
+			orderIDs = append(orderIDs, order.ID)
+		}
+
+		results, err := orderSvc.SetStatusBulk(orderIDs, model.Pending)
+		require.NoError(t, err)
+		for _, result := range results[:3] {
+			require.NoError(t, result.Err)
+		}
+		require.ErrorIs(t, results[3].Err, service.ErrRateLimited)
+	})
+
+	t.Run("should not limit with invalid settings", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			store  service.RateLimitStore
+			limit  int64
+			window time.Duration
+		}{
+			{"nil store", nil, 3, time.Minute},
+			{"zero limit", &mockRateLimitStore{counters: map[windowKey]int64{}}, 0, time.Minute},
+			{"negative limit", &mockRateLimitStore{counters: map[windowKey]int64{}}, -1, time.Minute},
+			{"zero window", &mockRateLimitStore{counters: map[windowKey]int64{}}, 3, 0},
+			{"negative window", &mockRateLimitStore{counters: map[windowKey]int64{}}, 3, -time.Minute},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				orderSvc := service.NewOrderService(modeltest.NewFakeOrderRepository(), modeltest.NewFakeEventDispatcher(),
+					service.WithCustomerRateLimit(tc.store, tc.limit, tc.window),
+				)
+				orderID, err := orderSvc.CreateOrder(customerID)
+				require.NoError(t, err)
+				for range 5 {
+					_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 10)
+					require.NoError(t, err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/repositorytest"
+)
+
+func TestFakeOrderRepositoryConformance(t *testing.T) {
+	repositorytest.RunConformanceTests(t, func(*testing.T) model.OrderRepository {
+		return modeltest.NewFakeOrderRepository()
+	})
+}
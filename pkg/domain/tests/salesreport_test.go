@@ -0,0 +1,194 @@
+package tests
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type salesKey struct {
+	day    time.Time
+	status model.OrderStatus
+}
+
+type orderSales struct {
+	key     salesKey
+	revenue float64
+}
+
+type mockSalesReportRepository struct {
+	sync.Mutex
+	rows   map[salesKey]*model.SalesRow
+	orders map[uuid.UUID]orderSales
+}
+
+func newMockSalesReportRepository() *mockSalesReportRepository {
+	return &mockSalesReportRepository{
+		rows:   map[salesKey]*model.SalesRow{},
+		orders: map[uuid.UUID]orderSales{},
+	}
+}
+
+func (m *mockSalesReportRepository) Record(orderID uuid.UUID, day time.Time, status model.OrderStatus, revenue float64) error {
+	m.Lock()
+	defer m.Unlock()
+	if previous, ok := m.orders[orderID]; ok {
+		row := m.rows[previous.key]
+		row.Orders--
+		row.Revenue -= previous.revenue
+	}
+
+	key := salesKey{day: day, status: status}
+	row, ok := m.rows[key]
+	if !ok {
+		row = &model.SalesRow{Period: day, Status: status}
+		m.rows[key] = row
+	}
+	row.Orders++
+	row.Revenue += revenue
+	m.orders[orderID] = orderSales{key: key, revenue: revenue}
+	return nil
+}
+
+func (m *mockSalesReportRepository) Remove(orderID uuid.UUID) error {
+	m.Lock()
+	defer m.Unlock()
+	if previous, ok := m.orders[orderID]; ok {
+		row := m.rows[previous.key]
+		row.Orders--
+		row.Revenue -= previous.revenue
+		delete(m.orders, orderID)
+	}
+	return nil
+}
+
+func (m *mockSalesReportRepository) FindDaily(from, to time.Time) ([]model.SalesRow, error) {
+	m.Lock()
+	defer m.Unlock()
+	var result []model.SalesRow
+	for _, row := range m.rows {
+		if row.Orders > 0 && !row.Period.Before(from) && row.Period.Before(to) {
+			result = append(result, *row)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Period.Equal(result[j].Period) {
+			return result[i].Period.Before(result[j].Period)
+		}
+		return result[i].Status < result[j].Status
+	})
+	return result, nil
+}
+
+func TestSalesReportProjection(t *testing.T) {
+	// 2025-01-06 is Monday
+	monday := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	clock := modeltest.NewFakeClock(monday)
+	repo := modeltest.NewFakeOrderRepository()
+	projection := service.NewSalesReportProjection(repo, newMockSalesReportRepository())
+	orderSvc := service.NewOrderService(repo, projection, service.WithClock(clock))
+
+	for _, days := range []int{0, 0, 1, 7} {
+		clock.Set(monday.AddDate(0, 0, days))
+		orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 10)
+		require.NoError(t, err)
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Paid))
+	}
+	clock.Set(monday.AddDate(0, 0, 1))
+	_, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+	require.NoError(t, err)
+	orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+	require.NoError(t, err)
+	_, err = orderSvc.SetStatusBulk([]uuid.UUID{orderID}, model.Cancelled, service.WithConsolidatedEvent())
+	require.NoError(t, err)
+
+	t.Run("should aggregate orders and revenue per day and status", func(t *testing.T) {
+		rows, err := projection.GetDailySales(monday, monday.AddDate(0, 0, 2))
+		require.NoError(t, err)
+
+		tuesday := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, []model.SalesRow{
+			{Period: tuesday.AddDate(0, 0, -1), Status: model.Paid, Orders: 2, Revenue: 20},
+			{Period: tuesday, Status: model.Open, Orders: 1},
+			{Period: tuesday, Status: model.Paid, Orders: 1, Revenue: 10},
+			{Period: tuesday, Status: model.Cancelled, Orders: 1},
+		}, rows)
+	})
+
+	t.Run("should roll up weeks", func(t *testing.T) {
+		rows, err := projection.GetWeeklySales(monday.AddDate(0, 0, 3), monday.AddDate(0, 0, 14))
+		require.NoError(t, err)
+
+		week := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, []model.SalesRow{
+			{Period: week, Status: model.Open, Orders: 1},
+			{Period: week, Status: model.Paid, Orders: 3, Revenue: 30},
+			{Period: week, Status: model.Cancelled, Orders: 1},
+			{Period: week.AddDate(0, 0, 7), Status: model.Paid, Orders: 1, Revenue: 10},
+		}, rows)
+	})
+
+	t.Run("should include the week containing to", func(t *testing.T) {
+		rows, err := projection.GetWeeklySales(monday, monday.AddDate(0, 0, 8))
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		require.Equal(t, model.SalesRow{Period: time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), Status: model.Paid, Orders: 1, Revenue: 10}, rows[3])
+
+		nextWeek := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+		rows, err = projection.GetWeeklySales(monday, nextWeek)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+	})
+
+	t.Run("should count an order once and drop its revenue when order leaves paid", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		projection := service.NewSalesReportProjection(repo, newMockSalesReportRepository())
+		orderSvc := service.NewOrderService(repo, projection, service.WithClock(modeltest.NewFakeClock(monday)))
+		day := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+		orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 100)
+		require.NoError(t, err)
+		for _, status := range []model.OrderStatus{model.Paid, model.Paid, model.Pending, model.Paid} {
+			require.NoError(t, orderSvc.SetStatus(orderID, status))
+		}
+
+		rows, err := projection.GetDailySales(monday, monday.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Equal(t, []model.SalesRow{{Period: day, Status: model.Paid, Orders: 1, Revenue: 100}}, rows)
+
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Cancelled))
+		rows, err = projection.GetDailySales(monday, monday.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Equal(t, []model.SalesRow{{Period: day, Status: model.Cancelled, Orders: 1}}, rows)
+	})
+
+	t.Run("should stop counting deleted orders", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		projection := service.NewSalesReportProjection(repo, newMockSalesReportRepository())
+		orderSvc := service.NewOrderService(repo, projection, service.WithClock(modeltest.NewFakeClock(monday)))
+
+		orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		_, err = orderSvc.AddItem(orderID, uuid.Must(uuid.NewV7()), 100)
+		require.NoError(t, err)
+		require.NoError(t, orderSvc.SetStatus(orderID, model.Paid))
+
+		require.NoError(t, orderSvc.DeleteOrder(orderID))
+		rows, err := projection.GetDailySales(monday, monday.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Empty(t, rows)
+		require.NoError(t, projection.Dispatch(model.OrderDeleted{OrderID: orderID}))
+	})
+}
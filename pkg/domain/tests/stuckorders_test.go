@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type mockAlerter struct {
+	alerts []service.StuckOrdersAlert
+}
+
+func (m *mockAlerter) Alert(alert service.StuckOrdersAlert) error {
+	m.alerts = append(m.alerts, alert)
+	return nil
+}
+
+func TestStuckOrderMonitor(t *testing.T) {
+	t.Run("should alert about orders stuck beyond threshold", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		alerter := &mockAlerter{}
+		now := time.Now().UTC()
+
+		stuckOrderID := uuid.Must(uuid.NewV7())
+		require.NoError(t, repo.Store(&model.Order{ID: stuckOrderID, Status: model.Paid, UpdatedAt: now.Add(-72 * time.Hour)}))
+		require.NoError(t, repo.Store(&model.Order{ID: uuid.Must(uuid.NewV7()), Status: model.Paid, UpdatedAt: now.Add(-time.Hour)}))
+		require.NoError(t, repo.Store(&model.Order{ID: uuid.Must(uuid.NewV7()), Status: model.Open, UpdatedAt: now.Add(-72 * time.Hour)}))
+
+		monitor := service.NewStuckOrderMonitor(repo, alerter, map[model.OrderStatus]time.Duration{
+			model.Paid: 48 * time.Hour,
+		})
+
+		require.NoError(t, monitor.Check())
+		require.Len(t, alerter.alerts, 1)
+		require.Equal(t, model.Paid, alerter.alerts[0].Status)
+		require.Equal(t, []uuid.UUID{stuckOrderID}, alerter.alerts[0].OrderIDs)
+	})
+
+	t.Run("should not alert when there are no stuck orders", func(t *testing.T) {
+		alerter := &mockAlerter{}
+		monitor := service.NewStuckOrderMonitor(modeltest.NewFakeOrderRepository(), alerter, map[model.OrderStatus]time.Duration{
+			model.Pending: time.Hour,
+		})
+
+		require.NoError(t, monitor.Check())
+		require.Empty(t, alerter.alerts)
+	})
+
+	t.Run("should reject non-positive interval", func(t *testing.T) {
+		monitor := service.NewStuckOrderMonitor(modeltest.NewFakeOrderRepository(), &mockAlerter{}, nil)
+		for _, interval := range []time.Duration{0, -time.Second} {
+			err := monitor.Run(context.Background(), interval, func(error) {})
+			require.ErrorIs(t, err, service.ErrInvalidMonitorInterval)
+		}
+	})
+}
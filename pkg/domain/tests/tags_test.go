@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	for tag, expected := range map[string]string{
+		"Manual Review":   "manual-review",
+		"  fraud\tcheck ": "fraud-check",
+		"region:EU":       "region:eu",
+	} {
+		normalized, err := model.NormalizeTag(tag)
+		require.NoError(t, err)
+		require.Equal(t, expected, normalized)
+	}
+
+	for _, tag := range []string{"", "   ", "manual/review", "ünicode"} {
+		_, err := model.NormalizeTag(tag)
+		require.ErrorIs(t, err, model.ErrInvalidTag)
+	}
+}
+
+func TestOrderFilterTags(t *testing.T) {
+	order := modeltest.NewOrderBuilder().WithTags("manual-review", "region:eu").Build()
+
+	require.True(t, model.OrderFilter{Tags: []string{"Manual Review", "region:EU"}}.Matches(order))
+	require.True(t, model.OrderFilter{AnyTags: []string{"manual/review", " REGION:eu "}}.Matches(order))
+	require.False(t, model.OrderFilter{Tags: []string{"manual-review", "manual/review"}}.Matches(order))
+	require.False(t, model.OrderFilter{AnyTags: []string{"manual/review"}}.Matches(order))
+}
+
+func TestOrderTags(t *testing.T) {
+	limitedCustomer := uuid.Must(uuid.NewV7())
+	repo := modeltest.NewFakeOrderRepository()
+	dispatcher := modeltest.NewFakeEventDispatcher()
+	orderSvc := service.NewOrderService(repo, dispatcher,
+		service.WithMaxTagsPerOrder(2, map[uuid.UUID]int{limitedCustomer: 1}),
+	)
+
+	t.Run("should keep tags normalized and sorted", func(t *testing.T) {
+		orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		require.NoError(t, orderSvc.AddTag(orderID, "Manual Review"))
+		require.NoError(t, orderSvc.AddTag(orderID, "fraud"))
+		require.NoError(t, orderSvc.AddTag(orderID, "manual-review"))
+
+		order, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"fraud", "manual-review"}, order.Tags)
+		require.Equal(t, []service.Event{
+			model.OrderTagsChanged{OrderID: orderID, AddedTags: []string{"manual-review"}},
+			model.OrderTagsChanged{OrderID: orderID, AddedTags: []string{"fraud"}},
+		}, dispatcher.Events())
+	})
+
+	t.Run("should remove tag", func(t *testing.T) {
+		orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.NoError(t, orderSvc.AddTag(orderID, "fraud"))
+
+		require.NoError(t, orderSvc.RemoveTag(orderID, "FRAUD"))
+		require.ErrorIs(t, orderSvc.RemoveTag(orderID, "fraud"), service.ErrTagNotFound)
+
+		order, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Empty(t, order.Tags)
+	})
+
+	t.Run("should apply customer tag limit", func(t *testing.T) {
+		orderID, err := orderSvc.CreateOrder(limitedCustomer)
+		require.NoError(t, err)
+
+		require.NoError(t, orderSvc.AddTag(orderID, "vip"))
+		require.ErrorIs(t, orderSvc.AddTag(orderID, "fraud"), service.ErrTagsLimitExceeded)
+		require.NoError(t, orderSvc.AddTag(orderID, "vip"))
+	})
+
+	t.Run("should reject invalid tag", func(t *testing.T) {
+		orderID, err := orderSvc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+
+		require.ErrorIs(t, orderSvc.AddTag(orderID, "manual/review"), model.ErrInvalidTag)
+	})
+}
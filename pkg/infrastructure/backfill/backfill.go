@@ -0,0 +1,194 @@
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+const defaultBatchSize = 100
+
+// Transform changes order in place and reports whether it has to be rewritten
+type Transform func(order *model.Order) (changed bool, err error)
+
+type Progress struct {
+	Total   int
+	Scanned int
+	Changed int
+	Written int
+}
+
+type Option func(o *options)
+
+// WithBatchSize sets number of orders loaded and written at once, size below 1 keeps the default
+func WithBatchSize(size int) Option {
+	return func(o *options) {
+		o.batchSize = size
+	}
+}
+
+// WithRateLimit limits number of scanned orders per second
+func WithRateLimit(ordersPerSecond float64) Option {
+	return func(o *options) {
+		o.ordersPerSecond = ordersPerSecond
+	}
+}
+
+// WithDryRun transforms and counts orders without writing them
+func WithDryRun() Option {
+	return func(o *options) {
+		o.dryRun = true
+	}
+}
+
+// WithProgress is called after every batch
+func WithProgress(report func(progress Progress)) Option {
+	return func(o *options) {
+		o.report = report
+	}
+}
+
+type options struct {
+	batchSize       int
+	ordersPerSecond float64
+	dryRun          bool
+	report          func(progress Progress)
+}
+
+type Backfiller interface {
+	// Run rewrites orders matching filter, soft deleted orders are not visible to it.
+	// Orders of a batch are locked while they are read, transformed and written, so concurrent commands aren't lost.
+	// Progress is returned also on error, so the run can be resumed with a narrower filter
+	Run(ctx context.Context, filter model.OrderFilter, transform Transform) (Progress, error)
+}
+
+// NewBackfiller takes the locker the order service locking middleware is configured with
+func NewBackfiller(repo model.OrderRepository, locker service.DistributedLocker, opts ...Option) Backfiller {
+	o := options{
+		batchSize: defaultBatchSize,
+		report:    func(Progress) {},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.batchSize <= 0 {
+		o.batchSize = defaultBatchSize
+	}
+
+	return &backfiller{
+		repo:    repo,
+		locker:  locker,
+		options: o,
+	}
+}
+
+type backfiller struct {
+	repo    model.OrderRepository
+	locker  service.DistributedLocker
+	options options
+}
+
+func (b *backfiller) Run(ctx context.Context, filter model.OrderFilter, transform Transform) (Progress, error) {
+	if err := ctx.Err(); err != nil {
+		return Progress{}, err
+	}
+
+	// IDs are collected first, writing while streaming could break cursors of storage adapters
+	var orderIDs []uuid.UUID
+	err := b.repo.StreamOrders(filter, func(order *model.Order) error {
+		orderIDs = append(orderIDs, order.ID)
+		return nil
+	})
+	if err != nil {
+		return Progress{}, err
+	}
+
+	progress := Progress{Total: len(orderIDs)}
+	for start := 0; start < len(orderIDs); start += b.options.batchSize {
+		if err = ctx.Err(); err != nil {
+			return progress, err
+		}
+
+		batchStart := time.Now()
+		batchIDs := orderIDs[start:min(start+b.options.batchSize, len(orderIDs))]
+		if err = b.runBatch(batchIDs, transform, &progress); err != nil {
+			return progress, err
+		}
+		b.options.report(progress)
+
+		if err = b.wait(ctx, batchStart, len(batchIDs)); err != nil {
+			return progress, err
+		}
+	}
+
+	return progress, nil
+}
+
+func (b *backfiller) runBatch(orderIDs []uuid.UUID, transform Transform, progress *Progress) (err error) {
+	unlock, err := service.LockOrders(b.locker, orderIDs)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	orders, err := b.repo.FindMany(orderIDs)
+	if err != nil {
+		return err
+	}
+
+	changed := make([]*model.Order, 0, len(orders))
+	for _, orderID := range orderIDs {
+		order, ok := orders[orderID]
+		if !ok {
+			// deleted after it was scanned
+			continue
+		}
+		progress.Scanned++
+
+		isChanged, err := transform(order)
+		if err != nil {
+			return err
+		}
+		if isChanged {
+			changed = append(changed, order)
+		}
+	}
+	progress.Changed += len(changed)
+
+	if b.options.dryRun || len(changed) == 0 {
+		return nil
+	}
+	if err = b.repo.StoreMany(changed); err != nil {
+		return err
+	}
+	progress.Written += len(changed)
+	return nil
+}
+
+// wait paces batches to the rate limit and stops waiting when ctx is done
+func (b *backfiller) wait(ctx context.Context, batchStart time.Time, batchSize int) error {
+	var delay time.Duration
+	if b.options.ordersPerSecond > 0 {
+		delay = time.Duration(float64(batchSize)/b.options.ordersPerSecond*float64(time.Second)) - time.Since(batchStart)
+	}
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
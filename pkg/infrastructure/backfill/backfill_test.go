@@ -0,0 +1,172 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+)
+
+var errTransform = errors.New("transform failed")
+
+// keyLocker tracks held keys, it fails instead of blocking on a held key
+type keyLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+func newLocker() *keyLocker {
+	return &keyLocker{held: make(map[string]bool)}
+}
+
+func (l *keyLocker) Lock(key string) (func() error, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held[key] {
+		return nil, errors.New("lock " + key + " is already held")
+	}
+	l.held[key] = true
+	return func() error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, key)
+		return nil
+	}, nil
+}
+
+func (l *keyLocker) isHeld(orderID uuid.UUID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.held["order:"+orderID.String()]
+}
+
+func setupRepository(t *testing.T) *modeltest.FakeOrderRepository {
+	t.Helper()
+	repo := modeltest.NewFakeOrderRepository()
+	for i := range 10 {
+		status := model.Open
+		if i%2 == 0 {
+			status = model.Pending
+		}
+		require.NoError(t, repo.Store(modeltest.NewOrderBuilder().WithStatus(status).WithItems(1).Build()))
+	}
+	return repo
+}
+
+// doublePrices rewrites only pending orders
+func doublePrices(order *model.Order) (bool, error) {
+	if order.Status != model.Pending {
+		return false, nil
+	}
+	for i := range order.Items {
+		order.Items[i].Price *= 2
+	}
+	return true, nil
+}
+
+func TestBackfiller(t *testing.T) {
+	t.Run("should rewrite changed orders in batches and report progress", func(t *testing.T) {
+		repo := setupRepository(t)
+		var reports []Progress
+		backfiller := NewBackfiller(repo, newLocker(), WithBatchSize(4), WithProgress(func(p Progress) {
+			reports = append(reports, p)
+		}))
+
+		progress, err := backfiller.Run(context.Background(), model.OrderFilter{}, doublePrices)
+		require.NoError(t, err)
+		require.Equal(t, Progress{Total: 10, Scanned: 10, Changed: 5, Written: 5}, progress)
+		require.Len(t, reports, 3)
+		require.Equal(t, 4, reports[0].Scanned)
+		require.Equal(t, 3, repo.CallCount("StoreMany"))
+	})
+
+	t.Run("should not write in dry run", func(t *testing.T) {
+		repo := setupRepository(t)
+
+		progress, err := NewBackfiller(repo, newLocker(), WithDryRun()).Run(context.Background(), model.OrderFilter{}, doublePrices)
+		require.NoError(t, err)
+		require.Equal(t, Progress{Total: 10, Scanned: 10, Changed: 5}, progress)
+		require.Zero(t, repo.CallCount("StoreMany"))
+	})
+
+	t.Run("should pace batches and stop on cancel", func(t *testing.T) {
+		repo := setupRepository(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		progress, err := NewBackfiller(repo, newLocker(), WithBatchSize(2), WithRateLimit(10)).Run(ctx, model.OrderFilter{}, doublePrices)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 2, progress.Scanned)
+	})
+
+	t.Run("should fall back to default batch size", func(t *testing.T) {
+		for _, size := range []int{0, -1} {
+			repo := setupRepository(t)
+
+			progress, err := NewBackfiller(repo, newLocker(), WithBatchSize(size)).Run(context.Background(), model.OrderFilter{}, doublePrices)
+			require.NoError(t, err)
+			require.Equal(t, Progress{Total: 10, Scanned: 10, Changed: 5, Written: 5}, progress)
+			require.Equal(t, 1, repo.CallCount("StoreMany"))
+		}
+	})
+
+	t.Run("should not start when ctx is done", func(t *testing.T) {
+		repo := setupRepository(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		progress, err := NewBackfiller(repo, newLocker()).Run(ctx, model.OrderFilter{}, doublePrices)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, Progress{}, progress)
+		require.Zero(t, repo.CallCount("FindMany"))
+	})
+
+	t.Run("should stop on transform error", func(t *testing.T) {
+		repo := setupRepository(t)
+
+		_, err := NewBackfiller(repo, newLocker()).Run(context.Background(), model.OrderFilter{}, func(*model.Order) (bool, error) {
+			return false, errTransform
+		})
+		require.ErrorIs(t, err, errTransform)
+	})
+
+	t.Run("should hold order locks while batch is rewritten", func(t *testing.T) {
+		repo := setupRepository(t)
+		locker := newLocker()
+		var unlocked []uuid.UUID
+
+		_, err := NewBackfiller(repo, locker, WithBatchSize(3)).Run(context.Background(), model.OrderFilter{}, func(order *model.Order) (bool, error) {
+			if !locker.isHeld(order.ID) {
+				unlocked = append(unlocked, order.ID)
+			}
+			return doublePrices(order)
+		})
+		require.NoError(t, err)
+		require.Empty(t, unlocked)
+		require.Empty(t, locker.held)
+	})
+
+	t.Run("should not rewrite batch locked by another writer", func(t *testing.T) {
+		repo := setupRepository(t)
+		locker := newLocker()
+		var orderIDs []uuid.UUID
+		require.NoError(t, repo.StreamOrders(model.OrderFilter{}, func(order *model.Order) error {
+			orderIDs = append(orderIDs, order.ID)
+			return nil
+		}))
+		_, err := locker.Lock("order:" + orderIDs[0].String())
+		require.NoError(t, err)
+
+		progress, err := NewBackfiller(repo, locker).Run(context.Background(), model.OrderFilter{}, doublePrices)
+		require.Error(t, err)
+		require.Zero(t, progress.Scanned)
+		require.Zero(t, repo.CallCount("StoreMany"))
+	})
+}
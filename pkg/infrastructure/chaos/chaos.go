@@ -0,0 +1,186 @@
+package chaos
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var ErrInjected = errors.New("injected failure")
+
+const (
+	MethodNextID       = "NextID"
+	MethodStore        = "Store"
+	MethodStoreMany    = "StoreMany"
+	MethodFind         = "Find"
+	MethodFindMany     = "FindMany"
+	MethodStreamOrders = "StreamOrders"
+	MethodDelete       = "Delete"
+	MethodPurge        = "Purge"
+	MethodDispatch     = "Dispatch"
+)
+
+type Fault struct {
+	// Latency is added to every call of the method
+	Latency time.Duration
+	// ErrorRate is probability from 0 to 1 that the call fails
+	ErrorRate float64
+	// Err is returned by failed calls, ErrInjected by default
+	Err error
+	// AfterCall makes failed calls reach the wrapped implementation first, so the write happens but the caller sees an error
+	AfterCall bool
+}
+
+type Option func(o *options)
+
+func WithFault(method string, fault Fault) Option {
+	return func(o *options) {
+		o.faults[method] = fault
+	}
+}
+
+// WithRandom replaces source of probabilities, it must be safe for concurrent use
+func WithRandom(random func() float64) Option {
+	return func(o *options) {
+		o.random = random
+	}
+}
+
+type options struct {
+	faults map[string]Fault
+	random func() float64
+}
+
+func newInjector(opts []Option) injector {
+	o := options{
+		faults: make(map[string]Fault),
+		random: rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return injector(o)
+}
+
+type injector options
+
+// call runs fn with fault configured for method
+func (i injector) call(method string, fn func() error) error {
+	fault, ok := i.faults[method]
+	if !ok {
+		return fn()
+	}
+
+	time.Sleep(fault.Latency)
+	if fault.ErrorRate <= 0 || i.random() >= fault.ErrorRate {
+		return fn()
+	}
+
+	if fault.AfterCall {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	if fault.Err != nil {
+		return fault.Err
+	}
+	return ErrInjected
+}
+
+func NewOrderRepository(repo model.OrderRepository, opts ...Option) model.OrderRepository {
+	return &orderRepository{
+		repo:     repo,
+		injector: newInjector(opts),
+	}
+}
+
+type orderRepository struct {
+	repo     model.OrderRepository
+	injector injector
+}
+
+func (r *orderRepository) NextID() (id uuid.UUID, err error) {
+	err = r.injector.call(MethodNextID, func() error {
+		id, err = r.repo.NextID()
+		return err
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *orderRepository) Store(order *model.Order) error {
+	return r.injector.call(MethodStore, func() error {
+		return r.repo.Store(order)
+	})
+}
+
+func (r *orderRepository) StoreMany(orders []*model.Order) error {
+	return r.injector.call(MethodStoreMany, func() error {
+		return r.repo.StoreMany(orders)
+	})
+}
+
+func (r *orderRepository) Find(id uuid.UUID) (order *model.Order, err error) {
+	err = r.injector.call(MethodFind, func() error {
+		order, err = r.repo.Find(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *orderRepository) FindMany(ids []uuid.UUID) (orders map[uuid.UUID]*model.Order, err error) {
+	err = r.injector.call(MethodFindMany, func() error {
+		orders, err = r.repo.FindMany(ids)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) StreamOrders(filter model.OrderFilter, fn func(order *model.Order) error) error {
+	return r.injector.call(MethodStreamOrders, func() error {
+		return r.repo.StreamOrders(filter, fn)
+	})
+}
+
+func (r *orderRepository) Delete(id uuid.UUID) error {
+	return r.injector.call(MethodDelete, func() error {
+		return r.repo.Delete(id)
+	})
+}
+
+func (r *orderRepository) Purge(id uuid.UUID) error {
+	return r.injector.call(MethodPurge, func() error {
+		return r.repo.Purge(id)
+	})
+}
+
+func NewEventDispatcher(dispatcher service.EventDispatcher, opts ...Option) service.EventDispatcher {
+	return &eventDispatcher{
+		dispatcher: dispatcher,
+		injector:   newInjector(opts),
+	}
+}
+
+type eventDispatcher struct {
+	dispatcher service.EventDispatcher
+	injector   injector
+}
+
+func (d *eventDispatcher) Dispatch(event service.Event) error {
+	return d.injector.call(MethodDispatch, func() error {
+		return d.dispatcher.Dispatch(event)
+	})
+}
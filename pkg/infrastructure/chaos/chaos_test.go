@@ -0,0 +1,76 @@
+package chaos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+)
+
+var errConfigured = errors.New("configured failure")
+
+func fixedRandom(value float64) Option {
+	return WithRandom(func() float64 {
+		return value
+	})
+}
+
+func TestOrderRepository(t *testing.T) {
+	t.Run("should pass calls through without faults", func(t *testing.T) {
+		inner := modeltest.NewFakeOrderRepository()
+		repo := NewOrderRepository(inner)
+		order := modeltest.NewOrderBuilder().Build()
+
+		require.NoError(t, repo.Store(order))
+		found, err := repo.Find(order.ID)
+		require.NoError(t, err)
+		require.Equal(t, order.ID, found.ID)
+	})
+
+	t.Run("should fail before call", func(t *testing.T) {
+		inner := modeltest.NewFakeOrderRepository()
+		repo := NewOrderRepository(inner, WithFault(MethodStore, Fault{ErrorRate: 0.5}), fixedRandom(0.1))
+
+		require.ErrorIs(t, repo.Store(modeltest.NewOrderBuilder().Build()), ErrInjected)
+		require.Zero(t, inner.CallCount("Store"))
+	})
+
+	t.Run("should not fail above error rate", func(t *testing.T) {
+		repo := NewOrderRepository(modeltest.NewFakeOrderRepository(), WithFault(MethodStore, Fault{ErrorRate: 0.5}), fixedRandom(0.7))
+
+		require.NoError(t, repo.Store(modeltest.NewOrderBuilder().Build()))
+	})
+
+	t.Run("should write and fail after call", func(t *testing.T) {
+		inner := modeltest.NewFakeOrderRepository()
+		repo := NewOrderRepository(inner, WithFault(MethodStore, Fault{ErrorRate: 1, Err: errConfigured, AfterCall: true}))
+		order := modeltest.NewOrderBuilder().Build()
+
+		require.ErrorIs(t, repo.Store(order), errConfigured)
+		_, err := inner.Find(order.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("should add latency", func(t *testing.T) {
+		repo := NewOrderRepository(modeltest.NewFakeOrderRepository(), WithFault(MethodFind, Fault{Latency: 20 * time.Millisecond}))
+		order := modeltest.NewOrderBuilder().Build()
+		require.NoError(t, repo.Store(order))
+
+		start := time.Now()
+		_, err := repo.Find(order.ID)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+}
+
+func TestEventDispatcher(t *testing.T) {
+	inner := modeltest.NewFakeEventDispatcher()
+	dispatcher := NewEventDispatcher(inner, WithFault(MethodDispatch, Fault{ErrorRate: 1}))
+
+	require.ErrorIs(t, dispatcher.Dispatch(model.OrderCreated{}), ErrInjected)
+	require.Empty(t, inner.Events())
+}
@@ -0,0 +1,191 @@
+package dispatcher
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/metrics"
+)
+
+const (
+	bufferUtilizationMetric = "dispatcher.buffer.utilization"
+	rejectedEventsMetric    = "dispatcher.rejected"
+	spilledEventsMetric     = "dispatcher.spilled"
+)
+
+var (
+	ErrDispatcherOverloaded = errors.New("dispatcher buffer is full")
+	ErrDispatcherClosed     = errors.New("dispatcher is closed")
+	ErrNilSpillover         = errors.New("spillover dispatcher is nil")
+)
+
+type AsyncDispatcher interface {
+	service.EventDispatcher
+	// Close stops accepting events and waits until buffered events are delivered
+	Close() error
+}
+
+type AsyncOption func(o *asyncOptions)
+
+// WithBlockTimeout waits for free buffer space up to timeout when buffer is full, zero timeout waits forever
+func WithBlockTimeout(timeout time.Duration) AsyncOption {
+	return func(o *asyncOptions) {
+		o.overflow = overflowBlock
+		o.blockTimeout = timeout
+	}
+}
+
+// WithReject fails with ErrDispatcherOverloaded immediately when buffer is full
+func WithReject() AsyncOption {
+	return func(o *asyncOptions) {
+		o.overflow = overflowReject
+	}
+}
+
+// WithSpillover synchronously hands events to fallback (e.g. a durable store) when buffer is full.
+// Spilled events reach fallback ahead of events still buffered, so events of one order lose their order
+// across the two dispatchers
+func WithSpillover(fallback service.EventDispatcher) AsyncOption {
+	return func(o *asyncOptions) {
+		o.overflow = overflowSpill
+		o.spillover = fallback
+	}
+}
+
+func WithMetrics(recorder metrics.Recorder) AsyncOption {
+	return func(o *asyncOptions) {
+		o.recorder = recorder
+	}
+}
+
+// WithErrorHandler receives errors of asynchronous delivery, they are dropped by default
+func WithErrorHandler(handler func(event service.Event, err error)) AsyncOption {
+	return func(o *asyncOptions) {
+		o.errorHandler = handler
+	}
+}
+
+type overflowPolicy int
+
+const (
+	overflowBlock overflowPolicy = iota
+	overflowReject
+	overflowSpill
+)
+
+type asyncOptions struct {
+	overflow     overflowPolicy
+	blockTimeout time.Duration
+	spillover    service.EventDispatcher
+	recorder     metrics.Recorder
+	errorHandler func(event service.Event, err error)
+}
+
+// NewAsyncDispatcher delivers events to next from a single goroutine in dispatch order,
+// by default Dispatch blocks while buffer is full
+func NewAsyncDispatcher(next service.EventDispatcher, bufferSize int, opts ...AsyncOption) (AsyncDispatcher, error) {
+	o := asyncOptions{
+		recorder:     metrics.NewNopRecorder(),
+		errorHandler: func(service.Event, error) {},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.overflow == overflowSpill && o.spillover == nil {
+		return nil, ErrNilSpillover
+	}
+
+	d := &asyncDispatcher{
+		next:    next,
+		options: o,
+		events:  make(chan service.Event, bufferSize),
+		done:    make(chan struct{}),
+	}
+	go d.run()
+	return d, nil
+}
+
+type asyncDispatcher struct {
+	mu      sync.RWMutex
+	closed  bool
+	next    service.EventDispatcher
+	options asyncOptions
+	events  chan service.Event
+	done    chan struct{}
+}
+
+func (d *asyncDispatcher) Dispatch(event service.Event) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+
+	select {
+	case d.events <- event:
+		d.reportUtilization()
+		return nil
+	default:
+	}
+
+	switch d.options.overflow {
+	case overflowReject:
+		d.options.recorder.IncCounter(rejectedEventsMetric, nil)
+		return ErrDispatcherOverloaded
+	case overflowSpill:
+		d.options.recorder.IncCounter(spilledEventsMetric, nil)
+		return d.options.spillover.Dispatch(event)
+	default:
+		return d.block(event)
+	}
+}
+
+func (d *asyncDispatcher) Close() error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.events)
+	}
+	d.mu.Unlock()
+
+	<-d.done
+	return nil
+}
+
+func (d *asyncDispatcher) block(event service.Event) error {
+	if d.options.blockTimeout == 0 {
+		d.events <- event
+		d.reportUtilization()
+		return nil
+	}
+
+	timer := time.NewTimer(d.options.blockTimeout)
+	defer timer.Stop()
+	select {
+	case d.events <- event:
+		d.reportUtilization()
+		return nil
+	case <-timer.C:
+		d.options.recorder.IncCounter(rejectedEventsMetric, nil)
+		return ErrDispatcherOverloaded
+	}
+}
+
+func (d *asyncDispatcher) run() {
+	defer close(d.done)
+	for event := range d.events {
+		d.reportUtilization()
+		if err := d.next.Dispatch(event); err != nil {
+			d.options.errorHandler(event, err)
+		}
+	}
+}
+
+func (d *asyncDispatcher) reportUtilization() {
+	if cap(d.events) == 0 {
+		return
+	}
+	d.options.recorder.SetGauge(bufferUtilizationMetric, float64(len(d.events))/float64(cap(d.events)), nil)
+}
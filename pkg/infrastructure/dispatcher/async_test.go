@@ -0,0 +1,94 @@
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type blockingSink struct {
+	recordingSink
+	release chan struct{}
+}
+
+func (s *blockingSink) Dispatch(event service.Event) error {
+	<-s.release
+	return s.recordingSink.Dispatch(event)
+}
+
+func TestAsyncDispatcher(t *testing.T) {
+	event := func() service.Event {
+		return model.OrderCreated{OrderID: uuid.Must(uuid.NewV7())}
+	}
+
+	t.Run("should deliver buffered events on close", func(t *testing.T) {
+		s := &recordingSink{}
+		d, err := NewAsyncDispatcher(s, 10)
+		require.NoError(t, err)
+
+		for range 5 {
+			require.NoError(t, d.Dispatch(event()))
+		}
+		require.NoError(t, d.Close())
+		require.Len(t, s.events, 5)
+		require.ErrorIs(t, d.Dispatch(event()), ErrDispatcherClosed)
+	})
+
+	t.Run("should reject events when buffer is full", func(t *testing.T) {
+		s := &blockingSink{release: make(chan struct{})}
+		d, err := NewAsyncDispatcher(s, 1, WithReject())
+		require.NoError(t, err)
+
+		require.NoError(t, d.Dispatch(event()))
+		require.Eventually(t, func() bool { return d.Dispatch(event()) == nil }, time.Second, time.Millisecond)
+		require.ErrorIs(t, d.Dispatch(event()), ErrDispatcherOverloaded)
+
+		close(s.release)
+		require.NoError(t, d.Close())
+	})
+
+	t.Run("should fail after block timeout", func(t *testing.T) {
+		s := &blockingSink{release: make(chan struct{})}
+		d, err := NewAsyncDispatcher(s, 0, WithBlockTimeout(10*time.Millisecond))
+		require.NoError(t, err)
+
+		require.NoError(t, d.Dispatch(event()))
+		require.ErrorIs(t, d.Dispatch(event()), ErrDispatcherOverloaded)
+
+		close(s.release)
+		require.NoError(t, d.Close())
+	})
+
+	t.Run("should spill events to fallback when buffer is full", func(t *testing.T) {
+		s := &blockingSink{release: make(chan struct{})}
+		fallback := &recordingSink{}
+		d, err := NewAsyncDispatcher(s, 0, WithSpillover(fallback))
+		require.NoError(t, err)
+
+		// condition runs outside of the test goroutine, so the first error is passed back instead of asserted there
+		dispatchErrs := make(chan error, 1)
+		require.Eventually(t, func() bool {
+			if err := d.Dispatch(event()); err != nil {
+				dispatchErrs <- err
+				return true
+			}
+			fallback.Lock()
+			defer fallback.Unlock()
+			return len(fallback.events) > 0
+		}, time.Second, time.Millisecond)
+		require.Empty(t, dispatchErrs)
+
+		close(s.release)
+		require.NoError(t, d.Close())
+	})
+
+	t.Run("should reject nil spillover", func(t *testing.T) {
+		_, err := NewAsyncDispatcher(&recordingSink{}, 1, WithSpillover(nil))
+		require.ErrorIs(t, err, ErrNilSpillover)
+	})
+}
@@ -0,0 +1,32 @@
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/dispatchertest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+func TestFanOutDispatcherConformance(t *testing.T) {
+	dispatchertest.RunConformanceTests(t, func(_ *testing.T, sink service.EventDispatcher) dispatchertest.Subject {
+		return dispatchertest.Subject{
+			Dispatcher:  NewFanOutDispatcher(4, sink),
+			Synchronous: true,
+		}
+	})
+}
+
+func TestAsyncDispatcherConformance(t *testing.T) {
+	dispatchertest.RunConformanceTests(t, func(t *testing.T, sink service.EventDispatcher) dispatchertest.Subject {
+		d, err := NewAsyncDispatcher(sink, 16)
+		require.NoError(t, err)
+		return dispatchertest.Subject{
+			Dispatcher: d,
+			Flush: func() {
+				require.NoError(t, d.Close())
+			},
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package dispatcher
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+const orderingStripes = 64
+
+type aggregateEvent interface {
+	AggregateID() uuid.UUID
+}
+
+// NewFanOutDispatcher dispatches every event to all sinks concurrently and waits for all of them.
+// At most concurrency deliveries run at once across all Dispatch calls. A sink never receives two events
+// of the same order at the same time, so events dispatched one after another keep their order in every sink
+func NewFanOutDispatcher(concurrency int, sinks ...service.EventDispatcher) service.EventDispatcher {
+	if concurrency <= 0 {
+		concurrency = len(sinks)
+	}
+
+	fanOutSinks := make([]*sink, 0, len(sinks))
+	for _, s := range sinks {
+		fanOutSinks = append(fanOutSinks, &sink{dispatcher: s})
+	}
+
+	return &fanOutDispatcher{
+		sinks:     fanOutSinks,
+		semaphore: make(chan struct{}, concurrency),
+	}
+}
+
+type fanOutDispatcher struct {
+	sinks     []*sink
+	semaphore chan struct{}
+}
+
+type sink struct {
+	dispatcher service.EventDispatcher
+	stripes    [orderingStripes]sync.Mutex
+}
+
+func (d *fanOutDispatcher) Dispatch(event service.Event) error {
+	stripe := stripeIndex(event)
+	errs := make([]error, len(d.sinks))
+
+	var wg sync.WaitGroup
+	for i, s := range d.sinks {
+		wg.Add(1)
+		d.semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-d.semaphore
+				wg.Done()
+			}()
+
+			s.stripes[stripe].Lock()
+			defer s.stripes[stripe].Unlock()
+			errs[i] = s.dispatcher.Dispatch(event)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// stripeIndex maps events of one aggregate to the same stripe, events without aggregate share the first one
+func stripeIndex(event service.Event) int {
+	e, ok := event.(aggregateEvent)
+	if !ok {
+		return 0
+	}
+	id := e.AggregateID()
+
+	h := fnv.New32a()
+	_, _ = h.Write(id[:])
+	return int(h.Sum32() % orderingStripes)
+}
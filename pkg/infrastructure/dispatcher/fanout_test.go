@@ -0,0 +1,97 @@
+package dispatcher
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var errSink = errors.New("sink error")
+
+type recordingSink struct {
+	sync.Mutex
+	events   []service.Event
+	err      error
+	delay    time.Duration
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+}
+
+func (s *recordingSink) Dispatch(event service.Event) error {
+	current := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		seen := s.maxSeen.Load()
+		if current <= seen || s.maxSeen.CompareAndSwap(seen, current) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+
+	s.Lock()
+	defer s.Unlock()
+	s.events = append(s.events, event)
+	return s.err
+}
+
+func TestFanOutDispatcher(t *testing.T) {
+	t.Run("should deliver events to every sink in order", func(t *testing.T) {
+		first, second := &recordingSink{}, &recordingSink{}
+		d := NewFanOutDispatcher(2, first, second)
+
+		orderID := uuid.Must(uuid.NewV7())
+		events := []service.Event{
+			model.OrderCreated{OrderID: orderID},
+			model.OrderStatusChanged{OrderID: orderID, NewStatus: model.Paid},
+			model.OrderDeleted{OrderID: orderID},
+		}
+		for _, event := range events {
+			require.NoError(t, d.Dispatch(event))
+		}
+
+		require.Equal(t, events, first.events)
+		require.Equal(t, events, second.events)
+	})
+
+	t.Run("should report errors of failed sinks", func(t *testing.T) {
+		ok, failing := &recordingSink{}, &recordingSink{err: errSink}
+		d := NewFanOutDispatcher(2, ok, failing)
+
+		err := d.Dispatch(model.OrderCreated{OrderID: uuid.Must(uuid.NewV7())})
+		require.ErrorIs(t, err, errSink)
+		require.Len(t, ok.events, 1)
+	})
+
+	t.Run("should not deliver events of one order to a sink concurrently", func(t *testing.T) {
+		s := &recordingSink{delay: time.Millisecond}
+		d := NewFanOutDispatcher(4, s)
+
+		orderID := uuid.Must(uuid.NewV7())
+		errs := make(chan error, 8)
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- d.Dispatch(model.OrderDeleted{OrderID: orderID})
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		require.Len(t, s.events, 8)
+		require.Equal(t, int32(1), s.maxSeen.Load())
+	})
+}
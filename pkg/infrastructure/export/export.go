@@ -0,0 +1,162 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+type Column string
+
+const (
+	ColumnID         Column = "id"
+	ColumnCustomerID Column = "customer_id"
+	ColumnStatus     Column = "status"
+	ColumnCreatedAt  Column = "created_at"
+	ColumnUpdatedAt  Column = "updated_at"
+	ColumnItemCount  Column = "item_count"
+	ColumnItemsTotal Column = "items_total"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown export format")
+	ErrUnknownColumn = errors.New("unknown export column")
+)
+
+var DefaultColumns = []Column{
+	ColumnID,
+	ColumnCustomerID,
+	ColumnStatus,
+	ColumnCreatedAt,
+	ColumnUpdatedAt,
+	ColumnItemCount,
+	ColumnItemsTotal,
+}
+
+var columnValues = map[Column]func(order *model.Order) any{
+	ColumnID:         func(order *model.Order) any { return order.ID.String() },
+	ColumnCustomerID: func(order *model.Order) any { return order.CustomerID.String() },
+	ColumnStatus:     func(order *model.Order) any { return order.Status.String() },
+	ColumnCreatedAt:  func(order *model.Order) any { return order.CreatedAt.UTC().Format(time.RFC3339Nano) },
+	ColumnUpdatedAt:  func(order *model.Order) any { return order.UpdatedAt.UTC().Format(time.RFC3339Nano) },
+	ColumnItemCount:  func(order *model.Order) any { return len(order.Items) },
+	ColumnItemsTotal: func(order *model.Order) any {
+		var total float64
+		for _, item := range order.Items {
+			total += item.Price
+		}
+		return total
+	},
+}
+
+type Exporter interface {
+	// Export streams orders matching filter to w and returns number of exported orders
+	Export(w io.Writer, filter model.OrderFilter) (int, error)
+}
+
+// NewExporter exports DefaultColumns when no columns are given
+func NewExporter(repo model.OrderRepository, format Format, columns ...Column) (Exporter, error) {
+	if format != FormatCSV && format != FormatJSONL {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	for _, column := range columns {
+		if _, ok := columnValues[column]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, column)
+		}
+	}
+
+	return &exporter{
+		repo:    repo,
+		format:  format,
+		columns: columns,
+	}, nil
+}
+
+type exporter struct {
+	repo    model.OrderRepository
+	format  Format
+	columns []Column
+}
+
+func (e *exporter) Export(w io.Writer, filter model.OrderFilter) (int, error) {
+	var write func(order *model.Order) error
+	var flush func() error
+	switch e.format {
+	case FormatCSV:
+		csvWriter := csv.NewWriter(w)
+		header := make([]string, 0, len(e.columns))
+		for _, column := range e.columns {
+			header = append(header, string(column))
+		}
+		if err := csvWriter.Write(header); err != nil {
+			return 0, err
+		}
+		write = func(order *model.Order) error {
+			return csvWriter.Write(e.csvRecord(order))
+		}
+		flush = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	default:
+		buffered := bufio.NewWriter(w)
+		encoder := json.NewEncoder(buffered)
+		write = func(order *model.Order) error {
+			return encoder.Encode(e.jsonRecord(order))
+		}
+		flush = buffered.Flush
+	}
+
+	count := 0
+	err := e.repo.StreamOrders(filter, func(order *model.Order) error {
+		if err := write(order); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if flushErr := flush(); err == nil {
+		err = flushErr
+	}
+	return count, err
+}
+
+func (e *exporter) csvRecord(order *model.Order) []string {
+	record := make([]string, 0, len(e.columns))
+	for _, column := range e.columns {
+		switch value := columnValues[column](order).(type) {
+		case string:
+			record = append(record, value)
+		case int:
+			record = append(record, strconv.Itoa(value))
+		case float64:
+			record = append(record, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+	return record
+}
+
+func (e *exporter) jsonRecord(order *model.Order) map[Column]any {
+	record := make(map[Column]any, len(e.columns))
+	for _, column := range e.columns {
+		record[column] = columnValues[column](order)
+	}
+	return record
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+)
+
+var errWrite = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestExporter(t *testing.T) {
+	customerID := uuid.MustParse("01900000-0000-7000-8000-000000000002")
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	order := modeltest.NewOrderBuilder().
+		WithID(uuid.MustParse("01900000-0000-7000-8000-000000000001")).
+		WithCustomerID(customerID).
+		WithStatus(model.Paid).
+		WithCreatedAt(createdAt).
+		WithItem(uuid.Must(uuid.NewV7()), 10.5).
+		WithItem(uuid.Must(uuid.NewV7()), 2.25).
+		Build()
+	otherOrder := modeltest.NewOrderBuilder().Build()
+
+	repo := modeltest.NewFakeOrderRepository()
+	require.NoError(t, repo.StoreMany([]*model.Order{order, otherOrder}))
+	filter := model.OrderFilter{CustomerID: customerID}
+
+	t.Run("should export selected columns to CSV", func(t *testing.T) {
+		exporter, err := NewExporter(repo, FormatCSV, ColumnID, ColumnStatus, ColumnCreatedAt, ColumnItemCount, ColumnItemsTotal)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		count, err := exporter.Export(&out, filter)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Equal(t,
+			"id,status,created_at,item_count,items_total\n"+
+				"01900000-0000-7000-8000-000000000001,paid,2025-01-02T03:04:05Z,2,12.75\n",
+			out.String(),
+		)
+	})
+
+	t.Run("should export JSON lines", func(t *testing.T) {
+		exporter, err := NewExporter(repo, FormatJSONL, ColumnID, ColumnItemsTotal)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		count, err := exporter.Export(&out, model.OrderFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		require.Contains(t, out.String(), `{"id":"01900000-0000-7000-8000-000000000001","items_total":12.75}`+"\n")
+	})
+
+	t.Run("should reject unknown format and columns", func(t *testing.T) {
+		_, err := NewExporter(repo, "xml")
+		require.ErrorIs(t, err, ErrUnknownFormat)
+		_, err = NewExporter(repo, FormatCSV, "price")
+		require.ErrorIs(t, err, ErrUnknownColumn)
+	})
+
+	t.Run("should report write errors", func(t *testing.T) {
+		exporter, err := NewExporter(repo, FormatJSONL)
+		require.NoError(t, err)
+
+		_, err = exporter.Export(failingWriter{}, model.OrderFilter{})
+		require.ErrorIs(t, err, errWrite)
+	})
+}
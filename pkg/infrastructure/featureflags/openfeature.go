@@ -0,0 +1,43 @@
+package featureflags
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-feature/go-sdk/openfeature"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+// evaluationTimeout bounds remote providers, flags are evaluated inside order commands
+const evaluationTimeout = time.Second
+
+// NewOpenFeatureProvider evaluates flags with OpenFeature client, customer ID is the targeting key.
+// Flags which can't be evaluated are disabled and the error is passed to onError, nil onError drops it
+func NewOpenFeatureProvider(client openfeature.IClient, onError func(flag string, err error)) service.FeatureFlags {
+	if onError == nil {
+		onError = func(string, error) {}
+	}
+	return &openFeatureProvider{
+		client:  client,
+		onError: onError,
+	}
+}
+
+type openFeatureProvider struct {
+	client  openfeature.IClient
+	onError func(flag string, err error)
+}
+
+func (p *openFeatureProvider) Enabled(flag string, customerID uuid.UUID) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), evaluationTimeout)
+	defer cancel()
+
+	enabled, err := p.client.BooleanValue(ctx, flag, false, openfeature.NewEvaluationContext(customerID.String(), nil))
+	if err != nil {
+		p.onError(flag, err)
+		return false
+	}
+	return enabled
+}
@@ -0,0 +1,56 @@
+package featureflags
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenFeatureProvider(t *testing.T) {
+	listed := uuid.Must(uuid.NewV7())
+	listedOnly := func(flag memprovider.InMemoryFlag, flatCtx openfeature.FlattenedContext) (any, openfeature.ProviderResolutionDetail) {
+		return flatCtx[openfeature.TargetingKey] == listed.String(), openfeature.ProviderResolutionDetail{Reason: openfeature.TargetingMatchReason}
+	}
+	evaluator := memprovider.ContextEvaluator(&listedOnly)
+	require.NoError(t, openfeature.SetNamedProviderAndWait(t.Name(), memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"everyone": {
+			Key:            "everyone",
+			State:          memprovider.Enabled,
+			DefaultVariant: "on",
+			Variants:       map[string]any{"on": true},
+		},
+		"listed": {
+			Key:              "listed",
+			State:            memprovider.Enabled,
+			ContextEvaluator: evaluator,
+		},
+		"not-boolean": {
+			Key:            "not-boolean",
+			State:          memprovider.Enabled,
+			DefaultVariant: "on",
+			Variants:       map[string]any{"on": "yes"},
+		},
+	})))
+
+	var failed []string
+	provider := NewOpenFeatureProvider(openfeature.NewClient(t.Name()), func(flag string, _ error) {
+		failed = append(failed, flag)
+	})
+	other := uuid.Must(uuid.NewV7())
+
+	t.Run("should evaluate flags for customer", func(t *testing.T) {
+		require.True(t, provider.Enabled("everyone", other))
+		require.True(t, provider.Enabled("listed", listed))
+		require.False(t, provider.Enabled("listed", other))
+	})
+
+	t.Run("should disable flags failed to evaluate", func(t *testing.T) {
+		failed = nil
+		require.False(t, provider.Enabled("unknown", listed))
+		require.False(t, provider.Enabled("not-boolean", listed))
+		require.Equal(t, []string{"unknown", "not-boolean"}, failed)
+	})
+}
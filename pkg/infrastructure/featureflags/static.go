@@ -0,0 +1,60 @@
+package featureflags
+
+import (
+	"hash/fnv"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+// Flag is enabled for everyone, for listed customers, or for a stable percentage of customers
+type Flag struct {
+	Enabled    bool
+	Customers  []uuid.UUID
+	Percentage uint32
+}
+
+// NewStaticProvider evaluates flags from memory, unknown flags are disabled
+func NewStaticProvider(flags map[string]Flag) service.FeatureFlags {
+	provider := &staticProvider{flags: make(map[string]staticFlag, len(flags))}
+	for name, flag := range flags {
+		customers := make(map[uuid.UUID]struct{}, len(flag.Customers))
+		for _, customerID := range flag.Customers {
+			customers[customerID] = struct{}{}
+		}
+		provider.flags[name] = staticFlag{Flag: flag, customers: customers}
+	}
+	return provider
+}
+
+type staticFlag struct {
+	Flag
+	customers map[uuid.UUID]struct{}
+}
+
+type staticProvider struct {
+	flags map[string]staticFlag
+}
+
+func (p *staticProvider) Enabled(flag string, customerID uuid.UUID) bool {
+	f, ok := p.flags[flag]
+	if !ok {
+		return false
+	}
+	if f.Enabled {
+		return true
+	}
+	if _, ok = f.customers[customerID]; ok {
+		return true
+	}
+	if f.Percentage == 0 {
+		return false
+	}
+
+	// hashing flag with customer keeps rollouts of different flags independent
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(flag))
+	_, _ = h.Write(customerID[:])
+	return h.Sum32()%100 < f.Percentage
+}
@@ -0,0 +1,38 @@
+package featureflags
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticProvider(t *testing.T) {
+	listed := uuid.Must(uuid.NewV7())
+	provider := NewStaticProvider(map[string]Flag{
+		"everyone": {Enabled: true},
+		"listed":   {Customers: []uuid.UUID{listed}},
+		"half":     {Percentage: 50},
+	})
+
+	t.Run("should evaluate global and listed flags", func(t *testing.T) {
+		other := uuid.Must(uuid.NewV7())
+		require.True(t, provider.Enabled("everyone", other))
+		require.True(t, provider.Enabled("listed", listed))
+		require.False(t, provider.Enabled("listed", other))
+		require.False(t, provider.Enabled("unknown", listed))
+	})
+
+	t.Run("should roll out stable percentage of customers", func(t *testing.T) {
+		enabled := 0
+		for range 1000 {
+			customerID := uuid.New()
+			result := provider.Enabled("half", customerID)
+			require.Equal(t, result, provider.Enabled("half", customerID))
+			if result {
+				enabled++
+			}
+		}
+		require.InDelta(t, 500, enabled, 100)
+	})
+}
@@ -0,0 +1,147 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+var errConnectionUnavailable = errors.New("connection unavailable")
+
+type CheckFunc func(ctx context.Context) error
+
+type DependencyReport struct {
+	Name     string
+	Status   Status
+	Error    string
+	Duration time.Duration
+}
+
+type Report struct {
+	Status       Status
+	Dependencies []DependencyReport
+}
+
+type Checker interface {
+	Register(name string, check CheckFunc)
+	Check(ctx context.Context) Report
+}
+
+func NewChecker(timeout time.Duration) Checker {
+	return &checker{
+		timeout: timeout,
+		checks:  make(map[string]CheckFunc),
+	}
+}
+
+type checker struct {
+	sync.RWMutex
+	timeout time.Duration
+	checks  map[string]CheckFunc
+}
+
+func (c *checker) Register(name string, check CheckFunc) {
+	c.Lock()
+	defer c.Unlock()
+	c.checks[name] = check
+}
+
+// Check runs all registered checks concurrently, the report is down if at least one dependency is down
+func (c *checker) Check(ctx context.Context) Report {
+	c.RLock()
+	checks := make(map[string]CheckFunc, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		reports = make([]DependencyReport, 0, len(checks))
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report := runCheck(ctx, name, check)
+			mu.Lock()
+			reports = append(reports, report)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+
+	status := StatusUp
+	for _, report := range reports {
+		if report.Status == StatusDown {
+			status = StatusDown
+			break
+		}
+	}
+
+	return Report{
+		Status:       status,
+		Dependencies: reports,
+	}
+}
+
+func runCheck(ctx context.Context, name string, check CheckFunc) DependencyReport {
+	start := time.Now()
+	err := check(ctx)
+	report := DependencyReport{
+		Name:     name,
+		Status:   StatusUp,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		report.Status = StatusDown
+		report.Error = err.Error()
+	}
+	return report
+}
+
+func DBCheck(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+func GRPCConnCheck(conn *grpc.ClientConn) CheckFunc {
+	return func(ctx context.Context) error {
+		conn.Connect()
+		for {
+			state := conn.GetState()
+			switch state {
+			case connectivity.Ready:
+				return nil
+			case connectivity.Shutdown:
+				return errConnectionUnavailable
+			default:
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return errors.Join(errConnectionUnavailable, ctx.Err())
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var errCheck = errors.New("check failed")
+
+func up(context.Context) error {
+	return nil
+}
+
+func down(context.Context) error {
+	return errCheck
+}
+
+// hang blocks until the checker gives up on it
+func hang(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestChecker(t *testing.T) {
+	testCases := []struct {
+		name         string
+		checks       map[string]CheckFunc
+		status       Status
+		dependencies []DependencyReport
+	}{
+		{
+			name:   "no checks",
+			status: StatusUp,
+		},
+		{
+			name:   "all up",
+			checks: map[string]CheckFunc{"mysql": up, "grpc": up},
+			status: StatusUp,
+			dependencies: []DependencyReport{
+				{Name: "grpc", Status: StatusUp},
+				{Name: "mysql", Status: StatusUp},
+			},
+		},
+		{
+			name:   "one down",
+			checks: map[string]CheckFunc{"mysql": down, "grpc": up},
+			status: StatusDown,
+			dependencies: []DependencyReport{
+				{Name: "grpc", Status: StatusUp},
+				{Name: "mysql", Status: StatusDown, Error: errCheck.Error()},
+			},
+		},
+		{
+			name:   "timed out",
+			checks: map[string]CheckFunc{"mysql": hang, "grpc": up},
+			status: StatusDown,
+			dependencies: []DependencyReport{
+				{Name: "grpc", Status: StatusUp},
+				{Name: "mysql", Status: StatusDown, Error: context.DeadlineExceeded.Error()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker(10 * time.Millisecond)
+			for name, check := range tc.checks {
+				checker.Register(name, check)
+			}
+
+			report := checker.Check(context.Background())
+			require.Equal(t, tc.status, report.Status)
+			require.Len(t, report.Dependencies, len(tc.dependencies))
+			for i, dependency := range report.Dependencies {
+				dependency.Duration = 0
+				require.Equal(t, tc.dependencies[i], dependency)
+			}
+		})
+	}
+
+	t.Run("should replace check registered under the same name", func(t *testing.T) {
+		checker := NewChecker(time.Second)
+		checker.Register("mysql", down)
+		checker.Register("mysql", up)
+
+		require.Equal(t, StatusUp, checker.Check(context.Background()).Status)
+	})
+}
+
+func TestGRPCConnCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	dial := func(t *testing.T, addr string) *grpc.ClientConn {
+		t.Helper()
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		return conn
+	}
+	check := func(conn *grpc.ClientConn) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		return GRPCConnCheck(conn)(ctx)
+	}
+
+	t.Run("should pass when connection becomes ready", func(t *testing.T) {
+		conn := dial(t, listener.Addr().String())
+		defer conn.Close()
+
+		require.NoError(t, check(conn))
+	})
+
+	t.Run("should fail on closed connection", func(t *testing.T) {
+		conn := dial(t, listener.Addr().String())
+		require.NoError(t, conn.Close())
+
+		require.ErrorIs(t, check(conn), errConnectionUnavailable)
+	})
+
+	t.Run("should fail when server is unreachable", func(t *testing.T) {
+		unused, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := unused.Addr().String()
+		require.NoError(t, unused.Close())
+		conn := dial(t, addr)
+		defer conn.Close()
+
+		err = check(conn)
+		require.ErrorIs(t, err, errConnectionUnavailable)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
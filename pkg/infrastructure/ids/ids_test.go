@@ -0,0 +1,75 @@
+package ids
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+func requireIncreasing(t *testing.T, generator service.IDGenerator, clock *modeltest.FakeClock) []uuid.UUID {
+	t.Helper()
+
+	var ids []uuid.UUID
+	var previous uuid.UUID
+	for i := range 5000 {
+		switch {
+		case i == 2000:
+			clock.Advance(-time.Second)
+		case i%1000 == 0:
+			clock.Advance(time.Millisecond)
+		}
+
+		id, err := generator.NextID()
+		require.NoError(t, err)
+		require.Positive(t, bytes.Compare(id[:], previous[:]))
+		previous = id
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestULIDGenerator(t *testing.T) {
+	t.Run("should generate increasing IDs with time prefix", func(t *testing.T) {
+		start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		clock := modeltest.NewFakeClock(start)
+
+		ids := requireIncreasing(t, NewULIDGenerator(clock), clock)
+		var prefix [6]byte
+		putMillis(prefix[:], uint64(start.Add(time.Millisecond).UnixMilli()))
+		require.Equal(t, prefix[:], ids[0][:6])
+	})
+
+	t.Run("should encode canonical string", func(t *testing.T) {
+		require.Equal(t, "00000000000000000000000000", ULIDString(uuid.Nil))
+		require.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", ULIDString(uuid.Max))
+		require.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", ULIDString(uuid.MustParse("01563e3a-b5d3-d676-4c61-efb99302bd5b")))
+	})
+}
+
+func TestSnowflakeGenerator(t *testing.T) {
+	t.Run("should reject invalid node ID", func(t *testing.T) {
+		_, err := NewSnowflakeGenerator(MaxSnowflakeNodeID+1, modeltest.NewFakeClock(time.Now()))
+		require.ErrorIs(t, err, ErrInvalidNodeID)
+	})
+
+	t.Run("should generate increasing IDs with node ID", func(t *testing.T) {
+		start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		clock := modeltest.NewFakeClock(start)
+		generator, err := NewSnowflakeGenerator(42, clock)
+		require.NoError(t, err)
+
+		ids := requireIncreasing(t, generator, clock)
+		for _, id := range ids {
+			value := SnowflakeID(id)
+			require.Positive(t, value)
+			require.Equal(t, int64(42), value>>12&MaxSnowflakeNodeID)
+		}
+		require.Equal(t, start.Add(time.Millisecond).Sub(snowflakeEpoch).Milliseconds(), SnowflakeID(ids[0])>>22)
+	})
+}
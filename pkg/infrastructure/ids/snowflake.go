@@ -0,0 +1,74 @@
+package ids
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+
+	MaxSnowflakeNodeID = 1<<snowflakeNodeBits - 1
+
+	maxSnowflakeSequence = 1<<snowflakeSequenceBits - 1
+)
+
+var ErrInvalidNodeID = errors.New("snowflake node ID is out of range")
+
+// snowflakeEpoch keeps 41 bits of milliseconds enough until 2089
+var snowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// NewSnowflakeGenerator returns 64 bit snowflake IDs stored in the first 8 bytes of UUID, see SnowflakeID.
+// Replicas must use distinct node IDs, IDs of one node are strictly increasing
+func NewSnowflakeGenerator(nodeID int64, clock service.Clock) (service.IDGenerator, error) {
+	if nodeID < 0 || nodeID > MaxSnowflakeNodeID {
+		return nil, ErrInvalidNodeID
+	}
+	return &snowflakeGenerator{
+		nodeID: nodeID,
+		clock:  clock,
+	}, nil
+}
+
+type snowflakeGenerator struct {
+	mu       sync.Mutex
+	nodeID   int64
+	clock    service.Clock
+	lastMs   int64
+	sequence int64
+}
+
+func (g *snowflakeGenerator) NextID() (uuid.UUID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := g.clock.Now().Sub(snowflakeEpoch).Milliseconds()
+	if ms > g.lastMs {
+		g.lastMs = ms
+		g.sequence = 0
+	} else {
+		// same millisecond or clock went backwards, sequence overflow borrows the next millisecond
+		g.sequence++
+		if g.sequence > maxSnowflakeSequence {
+			g.lastMs++
+			g.sequence = 0
+		}
+	}
+
+	value := g.lastMs<<(snowflakeNodeBits+snowflakeSequenceBits) | g.nodeID<<snowflakeSequenceBits | g.sequence
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[:8], uint64(value))
+	return id, nil
+}
+
+// SnowflakeID returns snowflake value of ID created by snowflake generator
+func SnowflakeID(id uuid.UUID) int64 {
+	return int64(binary.BigEndian.Uint64(id[:8]))
+}
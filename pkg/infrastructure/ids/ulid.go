@@ -0,0 +1,92 @@
+package ids
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewULIDGenerator returns ULIDs in UUID form, IDs of one generator are strictly increasing
+// even within one millisecond or when clock goes backwards
+func NewULIDGenerator(clock service.Clock) service.IDGenerator {
+	return &ulidGenerator{
+		clock:   clock,
+		entropy: rand.Reader,
+	}
+}
+
+type ulidGenerator struct {
+	mu      sync.Mutex
+	clock   service.Clock
+	entropy io.Reader
+	lastMs  uint64
+	last    uuid.UUID
+}
+
+func (g *ulidGenerator) NextID() (uuid.UUID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.clock.Now().UnixMilli())
+	if ms <= g.lastMs && g.last != uuid.Nil {
+		return g.increment(), nil
+	}
+
+	var id uuid.UUID
+	putMillis(id[:6], ms)
+	if _, err := io.ReadFull(g.entropy, id[6:]); err != nil {
+		return uuid.Nil, err
+	}
+	g.lastMs = ms
+	g.last = id
+	return id, nil
+}
+
+// increment adds one to random part of the last ID, overflow moves to the next millisecond
+func (g *ulidGenerator) increment() uuid.UUID {
+	id := g.last
+	for i := len(id) - 1; i >= 6; i-- {
+		id[i]++
+		if id[i] != 0 {
+			g.last = id
+			return id
+		}
+	}
+
+	g.lastMs++
+	putMillis(id[:6], g.lastMs)
+	g.last = id
+	return id
+}
+
+// ULIDString returns canonical 26 characters Crockford base32 form of the ID
+func ULIDString(id uuid.UUID) string {
+	var out [26]byte
+	// 128 bits are encoded as 130 bits, so the first character holds only 3 bits
+	var acc uint32
+	bits := 2
+	pos := 0
+	for _, b := range id {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockfordAlphabet[(acc>>uint(bits))&0x1f]
+			pos++
+		}
+	}
+	return string(out[:])
+}
+
+func putMillis(dst []byte, ms uint64) {
+	for i := 5; i >= 0; i-- {
+		dst[i] = byte(ms)
+		ms >>= 8
+	}
+}
@@ -0,0 +1,114 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type StopFunc func(ctx context.Context) error
+
+type Shutdown interface {
+	// Add registers step, steps are stopped in registration order
+	Add(name string, stop StopFunc)
+	// AddWithTimeout registers step with its own deadline, so it runs even when earlier steps used up the shared one
+	AddWithTimeout(name string, timeout time.Duration, stop StopFunc)
+	// Run stops all steps sharing one deadline, failed or timed out step does not prevent the next ones
+	Run() error
+}
+
+func NewShutdown(timeout time.Duration, logger log.FieldLogger) Shutdown {
+	return &shutdown{
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+type step struct {
+	name    string
+	timeout time.Duration
+	stop    StopFunc
+}
+
+type shutdown struct {
+	timeout time.Duration
+	logger  log.FieldLogger
+	steps   []step
+}
+
+func (s *shutdown) Add(name string, stop StopFunc) {
+	s.steps = append(s.steps, step{name: name, stop: stop})
+}
+
+func (s *shutdown) AddWithTimeout(name string, timeout time.Duration, stop StopFunc) {
+	s.steps = append(s.steps, step{name: name, timeout: timeout, stop: stop})
+}
+
+func (s *shutdown) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var errs []error
+	for _, st := range s.steps {
+		start := time.Now()
+		err := s.stop(ctx, st)
+		loggerWithFields := s.logger.WithFields(log.Fields{
+			"step":     st.name,
+			"duration": time.Since(start).String(),
+		})
+		if err != nil {
+			loggerWithFields.Errorf("shutdown step failed: %v", err)
+			errs = append(errs, err)
+			continue
+		}
+		loggerWithFields.Infof("shutdown step finished")
+	}
+	return errors.Join(errs...)
+}
+
+func (s *shutdown) stop(sharedCtx context.Context, st step) error {
+	if st.timeout <= 0 {
+		return st.stop(sharedCtx)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), st.timeout)
+	defer cancel()
+	return st.stop(ctx)
+}
+
+// Blocking adapts a stop function without context, it is abandoned when the deadline is exceeded
+func Blocking(stop func() error) StopFunc {
+	return func(ctx context.Context) error {
+		done := make(chan error, 1)
+		go func() {
+			done <- stop()
+		}()
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Graceful runs graceful stop and falls back to force when the deadline is exceeded
+func Graceful(graceful, force func()) StopFunc {
+	return func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			graceful()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			force()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+var errStep = errors.New("step failed")
+
+func TestShutdown(t *testing.T) {
+	t.Run("should stop steps in order and continue after failures", func(t *testing.T) {
+		logger, _ := test.NewNullLogger()
+		s := NewShutdown(time.Second, logger)
+
+		var stopped []string
+		for _, name := range []string{"server", "dispatcher", "repositories"} {
+			s.Add(name, func(context.Context) error {
+				stopped = append(stopped, name)
+				if name == "dispatcher" {
+					return errStep
+				}
+				return nil
+			})
+		}
+
+		require.ErrorIs(t, s.Run(), errStep)
+		require.Equal(t, []string{"server", "dispatcher", "repositories"}, stopped)
+	})
+
+	t.Run("should force stop after deadline", func(t *testing.T) {
+		logger, _ := test.NewNullLogger()
+		s := NewShutdown(10*time.Millisecond, logger)
+
+		release := make(chan struct{})
+		forced := false
+		s.Add("server", Graceful(func() { <-release }, func() {
+			forced = true
+			close(release)
+		}))
+		s.Add("connections", Blocking(func() error {
+			return nil
+		}))
+
+		err := s.Run()
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.True(t, forced)
+	})
+
+	t.Run("should run step with own timeout after shared deadline", func(t *testing.T) {
+		logger, _ := test.NewNullLogger()
+		s := NewShutdown(10*time.Millisecond, logger)
+
+		s.Add("server", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		closed := false
+		s.AddWithTimeout("connections", time.Second, Blocking(func() error {
+			closed = true
+			return nil
+		}))
+
+		require.ErrorIs(t, s.Run(), context.DeadlineExceeded)
+		require.True(t, closed)
+	})
+}
@@ -0,0 +1,24 @@
+package logging
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+func NewAlerter(logger log.FieldLogger) service.Alerter {
+	return &alerter{logger: logger}
+}
+
+type alerter struct {
+	logger log.FieldLogger
+}
+
+func (a *alerter) Alert(alert service.StuckOrdersAlert) error {
+	a.logger.WithFields(log.Fields{
+		FieldStatus: alert.Status,
+		"threshold": alert.Threshold.String(),
+		"order_ids": alert.OrderIDs,
+	}).Warnf("%d orders are stuck in status", len(alert.OrderIDs))
+	return nil
+}
@@ -0,0 +1,187 @@
+package logging
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+const (
+	FieldOrderID    = "order_id"
+	FieldCustomerID = "customer_id"
+	FieldProductID  = "product_id"
+	FieldItemID     = "item_id"
+	FieldStatus     = "status"
+	FieldPrice      = "price"
+	FieldTag        = "tag"
+
+	redactedValue = "[REDACTED]"
+)
+
+type Option func(o *options)
+
+// WithSuccessSampling logs only one of every rate successful calls of method, failed calls are always logged
+func WithSuccessSampling(method string, rate uint64) Option {
+	return func(o *options) {
+		o.sampling[method] = rate
+	}
+}
+
+// WithRedactedFields replaces values of the given fields with a placeholder
+func WithRedactedFields(fields ...string) Option {
+	return func(o *options) {
+		for _, field := range fields {
+			o.redacted[field] = struct{}{}
+		}
+	}
+}
+
+type options struct {
+	sampling map[string]uint64
+	redacted map[string]struct{}
+}
+
+func NewOrderMiddleware(logger log.FieldLogger, opts ...Option) service.Middleware {
+	o := options{
+		sampling: make(map[string]uint64),
+		redacted: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next service.Order) service.Order {
+		return &orderService{
+			next:    next,
+			logger:  logger,
+			options: o,
+		}
+	}
+}
+
+type orderService struct {
+	next     service.Order
+	logger   log.FieldLogger
+	options  options
+	counters sync.Map
+}
+
+func (s *orderService) CreateOrder(customerID uuid.UUID, opts ...service.CreateOption) (orderID uuid.UUID, err error) {
+	defer s.log("CreateOrder", time.Now(), log.Fields{
+		FieldCustomerID: customerID,
+	}, &err)
+
+	return s.next.CreateOrder(customerID, opts...)
+}
+
+func (s *orderService) DeleteOrder(orderID uuid.UUID) (err error) {
+	defer s.log("DeleteOrder", time.Now(), log.Fields{
+		FieldOrderID: orderID,
+	}, &err)
+
+	return s.next.DeleteOrder(orderID)
+}
+
+func (s *orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) (err error) {
+	defer s.log("SetStatus", time.Now(), log.Fields{
+		FieldOrderID: orderID,
+		FieldStatus:  status,
+	}, &err)
+
+	return s.next.SetStatus(orderID, status)
+}
+
+func (s *orderService) SetStatusBulk(
+	orderIDs []uuid.UUID,
+	status model.OrderStatus,
+	opts ...service.BulkOption,
+) (results []service.BulkStatusResult, err error) {
+	defer s.log("SetStatusBulk", time.Now(), log.Fields{
+		FieldStatus:   status,
+		"order_count": len(orderIDs),
+	}, &err)
+
+	return s.next.SetStatusBulk(orderIDs, status, opts...)
+}
+
+func (s *orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (itemID uuid.UUID, err error) {
+	defer s.log("AddItem", time.Now(), log.Fields{
+		FieldOrderID:   orderID,
+		FieldProductID: productID,
+		FieldPrice:     price,
+	}, &err)
+
+	return s.next.AddItem(orderID, productID, price)
+}
+
+func (s *orderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) (err error) {
+	defer s.log("DeleteItem", time.Now(), log.Fields{
+		FieldOrderID: orderID,
+		FieldItemID:  itemID,
+	}, &err)
+
+	return s.next.DeleteItem(orderID, itemID)
+}
+
+func (s *orderService) AddTag(orderID uuid.UUID, tag string) (err error) {
+	defer s.log("AddTag", time.Now(), log.Fields{
+		FieldOrderID: orderID,
+		FieldTag:     tag,
+	}, &err)
+
+	return s.next.AddTag(orderID, tag)
+}
+
+func (s *orderService) RemoveTag(orderID uuid.UUID, tag string) (err error) {
+	defer s.log("RemoveTag", time.Now(), log.Fields{
+		FieldOrderID: orderID,
+		FieldTag:     tag,
+	}, &err)
+
+	return s.next.RemoveTag(orderID, tag)
+}
+
+func (s *orderService) GetOrder(orderID uuid.UUID) (order *model.Order, err error) {
+	defer s.log("GetOrder", time.Now(), log.Fields{
+		FieldOrderID: orderID,
+	}, &err)
+
+	return s.next.GetOrder(orderID)
+}
+
+func (s *orderService) log(method string, start time.Time, fields log.Fields, err *error) {
+	if *err == nil && !s.sampled(method) {
+		return
+	}
+
+	for field := range fields {
+		if _, ok := s.options.redacted[field]; ok {
+			fields[field] = redactedValue
+		}
+	}
+	fields["method"] = method
+	fields["duration"] = time.Since(start).String()
+
+	loggerWithFields := s.logger.WithFields(fields)
+	if *err != nil {
+		loggerWithFields.Errorf("call failed: %v", *err)
+		return
+	}
+	loggerWithFields.Infof("call finished")
+}
+
+func (s *orderService) sampled(method string) bool {
+	rate, ok := s.options.sampling[method]
+	if !ok || rate <= 1 {
+		return true
+	}
+
+	counter, _ := s.counters.LoadOrStore(method, new(atomic.Uint64))
+	return counter.(*atomic.Uint64).Add(1)%rate == 1
+}
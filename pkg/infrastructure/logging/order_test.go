@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var errStub = errors.New("stub error")
+
+type stubOrderService struct {
+	err error
+}
+
+func (s stubOrderService) CreateOrder(_ uuid.UUID, _ ...service.CreateOption) (uuid.UUID, error) {
+	return uuid.Nil, s.err
+}
+
+func (s stubOrderService) DeleteOrder(_ uuid.UUID) error {
+	return s.err
+}
+
+func (s stubOrderService) SetStatus(_ uuid.UUID, _ model.OrderStatus) error {
+	return s.err
+}
+
+func (s stubOrderService) SetStatusBulk(_ []uuid.UUID, _ model.OrderStatus, _ ...service.BulkOption) ([]service.BulkStatusResult, error) {
+	return nil, s.err
+}
+
+func (s stubOrderService) AddItem(_, _ uuid.UUID, _ float64) (uuid.UUID, error) {
+	return uuid.Nil, s.err
+}
+
+func (s stubOrderService) DeleteItem(_, _ uuid.UUID) error {
+	return s.err
+}
+
+func (s stubOrderService) AddTag(_ uuid.UUID, _ string) error {
+	return s.err
+}
+
+func (s stubOrderService) RemoveTag(_ uuid.UUID, _ string) error {
+	return s.err
+}
+
+func (s stubOrderService) GetOrder(_ uuid.UUID) (*model.Order, error) {
+	return nil, s.err
+}
+
+func TestOrderMiddleware(t *testing.T) {
+	t.Run("should sample successful calls", func(t *testing.T) {
+		logger, hook := test.NewNullLogger()
+		orderSvc := service.Chain(stubOrderService{}, NewOrderMiddleware(logger, WithSuccessSampling("AddItem", 10)))
+
+		for range 25 {
+			_, err := orderSvc.AddItem(uuid.New(), uuid.New(), 1)
+			require.NoError(t, err)
+		}
+		require.Len(t, hook.AllEntries(), 3)
+	})
+
+	t.Run("should always log failed calls", func(t *testing.T) {
+		logger, hook := test.NewNullLogger()
+		orderSvc := service.Chain(stubOrderService{err: errStub}, NewOrderMiddleware(logger, WithSuccessSampling("AddItem", 10)))
+
+		for range 5 {
+			_, err := orderSvc.AddItem(uuid.New(), uuid.New(), 1)
+			require.ErrorIs(t, err, errStub)
+		}
+		require.Len(t, hook.AllEntries(), 5)
+	})
+
+	t.Run("should redact configured fields", func(t *testing.T) {
+		logger, hook := test.NewNullLogger()
+		orderSvc := service.Chain(stubOrderService{}, NewOrderMiddleware(logger, WithRedactedFields(FieldCustomerID)))
+
+		_, err := orderSvc.CreateOrder(uuid.New())
+		require.NoError(t, err)
+
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		require.Equal(t, redactedValue, entry.Data[FieldCustomerID])
+		require.Equal(t, "CreateOrder", entry.Data["method"])
+	})
+}
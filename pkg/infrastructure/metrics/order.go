@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+const (
+	callsMetric        = "order.calls"
+	callDurationMetric = "order.call.duration"
+)
+
+// CustomerResolver returns customer of the order for per-customer dimensions
+type CustomerResolver func(orderID uuid.UUID) (uuid.UUID, error)
+
+func RepositoryCustomerResolver(repo model.OrderRepository) CustomerResolver {
+	return func(orderID uuid.UUID) (uuid.UUID, error) {
+		order, err := repo.Find(orderID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return order.CustomerID, nil
+	}
+}
+
+type Option func(o *options)
+
+// WithCustomerDimension adds customer_bucket tag, customers are hashed into buckets to bound cardinality.
+// Resolving customer by order ID costs an additional lookup per call
+func WithCustomerDimension(buckets uint32, resolver CustomerResolver) Option {
+	return func(o *options) {
+		o.customerBuckets = buckets
+		o.customerResolver = resolver
+	}
+}
+
+// WithTopCustomers counts calls per customer in tracker, requires customer resolver to track calls by order ID
+func WithTopCustomers(tracker CustomerTracker) Option {
+	return func(o *options) {
+		o.customerTracker = tracker
+	}
+}
+
+type options struct {
+	customerBuckets  uint32
+	customerResolver CustomerResolver
+	customerTracker  CustomerTracker
+}
+
+func NewOrderMiddleware(recorder Recorder, opts ...Option) service.Middleware {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next service.Order) service.Order {
+		return &orderService{
+			next:     next,
+			recorder: recorder,
+			options:  o,
+		}
+	}
+}
+
+type orderService struct {
+	next     service.Order
+	recorder Recorder
+	options  options
+}
+
+func (s *orderService) CreateOrder(customerID uuid.UUID, opts ...service.CreateOption) (orderID uuid.UUID, err error) {
+	defer s.record("CreateOrder", time.Now(), customerID, &err)
+	return s.next.CreateOrder(customerID, opts...)
+}
+
+func (s *orderService) DeleteOrder(orderID uuid.UUID) (err error) {
+	defer s.record("DeleteOrder", time.Now(), s.resolveCustomer(orderID), &err)
+	return s.next.DeleteOrder(orderID)
+}
+
+func (s *orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) (err error) {
+	defer s.record("SetStatus", time.Now(), s.resolveCustomer(orderID), &err)
+	return s.next.SetStatus(orderID, status)
+}
+
+func (s *orderService) SetStatusBulk(
+	orderIDs []uuid.UUID,
+	status model.OrderStatus,
+	opts ...service.BulkOption,
+) (results []service.BulkStatusResult, err error) {
+	defer s.record("SetStatusBulk", time.Now(), uuid.Nil, &err)
+	return s.next.SetStatusBulk(orderIDs, status, opts...)
+}
+
+func (s *orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (itemID uuid.UUID, err error) {
+	defer s.record("AddItem", time.Now(), s.resolveCustomer(orderID), &err)
+	return s.next.AddItem(orderID, productID, price)
+}
+
+func (s *orderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) (err error) {
+	defer s.record("DeleteItem", time.Now(), s.resolveCustomer(orderID), &err)
+	return s.next.DeleteItem(orderID, itemID)
+}
+
+func (s *orderService) AddTag(orderID uuid.UUID, tag string) (err error) {
+	defer s.record("AddTag", time.Now(), s.resolveCustomer(orderID), &err)
+	return s.next.AddTag(orderID, tag)
+}
+
+func (s *orderService) RemoveTag(orderID uuid.UUID, tag string) (err error) {
+	defer s.record("RemoveTag", time.Now(), s.resolveCustomer(orderID), &err)
+	return s.next.RemoveTag(orderID, tag)
+}
+
+func (s *orderService) GetOrder(orderID uuid.UUID) (order *model.Order, err error) {
+	defer s.record("GetOrder", time.Now(), uuid.Nil, &err)
+	return s.next.GetOrder(orderID)
+}
+
+// resolveCustomer returns uuid.Nil when customer is not needed or can't be resolved, the call itself reports the error
+func (s *orderService) resolveCustomer(orderID uuid.UUID) uuid.UUID {
+	if s.options.customerResolver == nil {
+		return uuid.Nil
+	}
+	customerID, err := s.options.customerResolver(orderID)
+	if err != nil {
+		return uuid.Nil
+	}
+	return customerID
+}
+
+func (s *orderService) record(method string, start time.Time, customerID uuid.UUID, err *error) {
+	result := "success"
+	if *err != nil {
+		result = "error"
+	}
+	tags := Tags{
+		"method": method,
+		"result": result,
+	}
+
+	if customerID != uuid.Nil {
+		if s.options.customerBuckets > 0 {
+			tags["customer_bucket"] = customerBucket(customerID, s.options.customerBuckets)
+		}
+		if s.options.customerTracker != nil {
+			s.options.customerTracker.Track(customerID)
+		}
+	}
+
+	s.recorder.IncCounter(callsMetric, tags)
+	s.recorder.ObserveDuration(callDurationMetric, time.Since(start), tags)
+}
+
+func customerBucket(customerID uuid.UUID, buckets uint32) string {
+	h := fnv.New32a()
+	_, _ = h.Write(customerID[:])
+	return strconv.FormatUint(uint64(h.Sum32()%buckets), 10)
+}
@@ -0,0 +1,24 @@
+package metrics
+
+import "time"
+
+type Tags map[string]string
+
+// Recorder abstracts metrics backend so instrumented decorators don't depend on a concrete exporter
+type Recorder interface {
+	IncCounter(name string, tags Tags)
+	ObserveDuration(name string, duration time.Duration, tags Tags)
+	SetGauge(name string, value float64, tags Tags)
+}
+
+func NewNopRecorder() Recorder {
+	return nopRecorder{}
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) IncCounter(string, Tags) {}
+
+func (nopRecorder) ObserveDuration(string, time.Duration, Tags) {}
+
+func (nopRecorder) SetGauge(string, float64, Tags) {}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type StatsDRecorder interface {
+	Recorder
+	Close() error
+}
+
+// NewStatsDRecorder sends metrics over UDP, with dogStatsD enabled tags are sent in DogStatsD format,
+// otherwise they are dropped since plain StatsD has no tags support
+func NewStatsDRecorder(address, prefix string, dogStatsD bool) (StatsDRecorder, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial statsd on %s", address)
+	}
+
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+
+	return &statsDRecorder{
+		conn:      conn,
+		prefix:    prefix,
+		dogStatsD: dogStatsD,
+	}, nil
+}
+
+type statsDRecorder struct {
+	conn      net.Conn
+	prefix    string
+	dogStatsD bool
+}
+
+func (r *statsDRecorder) IncCounter(name string, tags Tags) {
+	r.send(name, "1", "c", tags)
+}
+
+func (r *statsDRecorder) ObserveDuration(name string, duration time.Duration, tags Tags) {
+	r.send(name, strconv.FormatFloat(float64(duration)/float64(time.Millisecond), 'f', -1, 64), "ms", tags)
+}
+
+func (r *statsDRecorder) SetGauge(name string, value float64, tags Tags) {
+	r.send(name, strconv.FormatFloat(value, 'f', -1, 64), "g", tags)
+}
+
+func (r *statsDRecorder) Close() error {
+	return r.conn.Close()
+}
+
+// send writes metric without waiting for delivery, metrics are lost silently like in any StatsD client
+func (r *statsDRecorder) send(name, value, metricType string, tags Tags) {
+	var b strings.Builder
+	b.WriteString(r.prefix)
+	b.WriteString(name)
+	b.WriteByte(':')
+	b.WriteString(value)
+	b.WriteByte('|')
+	b.WriteString(metricType)
+
+	if r.dogStatsD && len(tags) > 0 {
+		keys := make([]string, 0, len(tags))
+		for key := range tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("|#")
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(key)
+			b.WriteByte(':')
+			b.WriteString(tags[key])
+		}
+	}
+
+	_, _ = r.conn.Write([]byte(b.String()))
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsDRecorder(t *testing.T) {
+	listen := func(t *testing.T) net.PacketConn {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	read := func(t *testing.T, conn net.PacketConn) string {
+		buf := make([]byte, 1024)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+
+	t.Run("should send counters with DogStatsD tags", func(t *testing.T) {
+		conn := listen(t)
+		recorder, err := NewStatsDRecorder(conn.LocalAddr().String(), "orderservice", true)
+		require.NoError(t, err)
+		defer recorder.Close()
+
+		recorder.IncCounter("order.calls", Tags{"result": "success", "method": "AddItem"})
+		require.Equal(t, "orderservice.order.calls:1|c|#method:AddItem,result:success", read(t, conn))
+	})
+
+	t.Run("should drop tags for plain StatsD", func(t *testing.T) {
+		conn := listen(t)
+		recorder, err := NewStatsDRecorder(conn.LocalAddr().String(), "", false)
+		require.NoError(t, err)
+		defer recorder.Close()
+
+		recorder.ObserveDuration("order.call.duration", 1500*time.Microsecond, Tags{"method": "AddItem"})
+		require.Equal(t, "order.call.duration:1.5|ms", read(t, conn))
+	})
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var _ service.CustomerRelinker = &topCustomersTracker{}
+
+type CustomerCount struct {
+	CustomerID uuid.UUID
+	Count      uint64
+}
+
+type CustomerTracker interface {
+	Track(customerID uuid.UUID)
+	Top(n int) []CustomerCount
+	// RelinkCustomer moves count of the customer to pseudonym
+	RelinkCustomer(customerID, pseudonym uuid.UUID) error
+}
+
+// NewTopCustomersTracker tracks heaviest customers with Space-Saving algorithm,
+// memory is bounded by capacity and counts of evicted customers may be overestimated. Capacity is at least 1
+func NewTopCustomersTracker(capacity int) CustomerTracker {
+	capacity = max(capacity, 1)
+	return &topCustomersTracker{
+		capacity: capacity,
+		counts:   make(map[uuid.UUID]uint64, capacity),
+	}
+}
+
+type topCustomersTracker struct {
+	sync.Mutex
+	capacity int
+	counts   map[uuid.UUID]uint64
+}
+
+func (t *topCustomersTracker) Track(customerID uuid.UUID) {
+	t.Lock()
+	defer t.Unlock()
+
+	if _, ok := t.counts[customerID]; ok || len(t.counts) < t.capacity {
+		t.counts[customerID]++
+		return
+	}
+
+	var (
+		minID    uuid.UUID
+		minCount uint64
+		first    = true
+	)
+	for id, count := range t.counts {
+		if first || count < minCount {
+			minID, minCount, first = id, count, false
+		}
+	}
+	delete(t.counts, minID)
+	t.counts[customerID] = minCount + 1
+}
+
+func (t *topCustomersTracker) RelinkCustomer(customerID, pseudonym uuid.UUID) error {
+	t.Lock()
+	defer t.Unlock()
+	count, ok := t.counts[customerID]
+	if !ok {
+		return nil
+	}
+	delete(t.counts, customerID)
+	// the customer's slot is free, so pseudonym fits even when it is not tracked yet
+	t.counts[pseudonym] += count
+	return nil
+}
+
+func (t *topCustomersTracker) Top(n int) []CustomerCount {
+	t.Lock()
+	result := make([]CustomerCount, 0, len(t.counts))
+	for id, count := range t.counts {
+		result = append(result, CustomerCount{CustomerID: id, Count: count})
+	}
+	t.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].CustomerID.String() < result[j].CustomerID.String()
+	})
+	n = max(n, 0)
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
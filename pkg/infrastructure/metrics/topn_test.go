@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopCustomersTracker(t *testing.T) {
+	t.Run("should return heaviest customers first", func(t *testing.T) {
+		tracker := NewTopCustomersTracker(10)
+		heavy, medium, light := uuid.New(), uuid.New(), uuid.New()
+		for range 5 {
+			tracker.Track(heavy)
+		}
+		for range 3 {
+			tracker.Track(medium)
+		}
+		tracker.Track(light)
+
+		require.Equal(t, []CustomerCount{
+			{CustomerID: heavy, Count: 5},
+			{CustomerID: medium, Count: 3},
+		}, tracker.Top(2))
+	})
+
+	t.Run("should keep heavy customer when capacity is exceeded", func(t *testing.T) {
+		tracker := NewTopCustomersTracker(5)
+		heavy := uuid.New()
+		for range 10 {
+			tracker.Track(heavy)
+		}
+		for range 20 {
+			tracker.Track(uuid.New())
+		}
+
+		top := tracker.Top(1)
+		require.Len(t, top, 1)
+		require.Equal(t, heavy, top[0].CustomerID)
+		require.Len(t, tracker.Top(10), 5)
+	})
+
+	t.Run("should clamp invalid capacity and limit", func(t *testing.T) {
+		tracker := NewTopCustomersTracker(-1)
+		tracker.Track(uuid.New())
+		tracker.Track(uuid.New())
+
+		require.Len(t, tracker.Top(10), 1)
+		require.Empty(t, tracker.Top(-1))
+	})
+
+	t.Run("should move count of relinked customer to pseudonym", func(t *testing.T) {
+		tracker := NewTopCustomersTracker(2)
+		erased, other, pseudonym := uuid.New(), uuid.New(), uuid.New()
+		tracker.Track(erased)
+		tracker.Track(erased)
+		tracker.Track(other)
+
+		require.NoError(t, tracker.RelinkCustomer(erased, pseudonym))
+		require.NoError(t, tracker.RelinkCustomer(erased, pseudonym))
+		require.Equal(t, []CustomerCount{{CustomerID: pseudonym, Count: 2}, {CustomerID: other, Count: 1}}, tracker.Top(10))
+	})
+}
@@ -0,0 +1,141 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+func NewCustomerStatsRepository(db *sqlx.DB) model.CustomerStatsRepository {
+	return &customerStatsRepository{db: db}
+}
+
+type customerStatsRepository struct {
+	db *sqlx.DB
+}
+
+type sqlCustomerStats struct {
+	OrderCount  int        `db:"order_count"`
+	TotalSpend  float64    `db:"total_spend"`
+	LastOrderAt *time.Time `db:"last_order_at"`
+}
+
+func (r *customerStatsRepository) RecordOrder(customerID uuid.UUID, orderedAt time.Time) error {
+	const query = `
+		INSERT INTO customer_stats (customer_id, order_count, last_order_at)
+		VALUES (?, 1, ?)
+		ON DUPLICATE KEY UPDATE
+			order_count = order_count + 1,
+			last_order_at = GREATEST(COALESCE(last_order_at, VALUES(last_order_at)), VALUES(last_order_at))
+	`
+	_, err := r.db.Exec(query, customerID[:], orderedAt)
+	return err
+}
+
+func (r *customerStatsRepository) SetOrderSpend(customerID, orderID uuid.UUID, amount float64) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// the order row is locked, so concurrent updates of one order apply their deltas one after another
+	var previous float64
+	err = tx.Get(&previous, "SELECT amount FROM customer_order_spend WHERE order_id = ? FOR UPDATE", orderID[:])
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if amount == 0 {
+		_, err = tx.Exec("DELETE FROM customer_order_spend WHERE order_id = ?", orderID[:])
+	} else {
+		_, err = tx.Exec(`
+			INSERT INTO customer_order_spend (order_id, customer_id, amount)
+			VALUES (?, ?, ?)
+			ON DUPLICATE KEY UPDATE amount = VALUES(amount)
+		`, orderID[:], customerID[:], amount)
+	}
+	if err != nil {
+		return err
+	}
+
+	if delta := amount - previous; delta != 0 {
+		_, err = tx.Exec(`
+			INSERT INTO customer_stats (customer_id, total_spend)
+			VALUES (?, ?)
+			ON DUPLICATE KEY UPDATE total_spend = total_spend + VALUES(total_spend)
+		`, customerID[:], delta)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *customerStatsRepository) Relink(customerID, pseudonym uuid.UUID) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec("UPDATE customer_order_spend SET customer_id = ? WHERE customer_id = ?", pseudonym[:], customerID[:]); err != nil {
+		return err
+	}
+	// pseudonym may already have stats, e.g. spend of a relinked order changed before stats were relinked
+	_, err = tx.Exec(`
+		INSERT INTO customer_stats (customer_id, order_count, total_spend, last_order_at)
+		SELECT ?, order_count, total_spend, last_order_at
+		FROM customer_stats
+		WHERE customer_id = ?
+		ON DUPLICATE KEY UPDATE
+			order_count = order_count + VALUES(order_count),
+			total_spend = total_spend + VALUES(total_spend),
+			last_order_at = GREATEST(COALESCE(last_order_at, VALUES(last_order_at)), COALESCE(VALUES(last_order_at), last_order_at))
+	`, pseudonym[:], customerID[:])
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM customer_stats WHERE customer_id = ?", customerID[:]); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *customerStatsRepository) Find(customerID uuid.UUID) (*model.CustomerStats, error) {
+	const query = `
+		SELECT order_count, total_spend, last_order_at
+		FROM customer_stats
+		WHERE customer_id = ?
+	`
+
+	var stats sqlCustomerStats
+	err := r.db.Get(&stats, query, customerID[:])
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrCustomerStatsNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CustomerStats{
+		CustomerID:  customerID,
+		OrderCount:  stats.OrderCount,
+		TotalSpend:  stats.TotalSpend,
+		LastOrderAt: stats.LastOrderAt,
+	}, nil
+}
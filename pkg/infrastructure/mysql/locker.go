@@ -0,0 +1,144 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+// connTimeout bounds waiting for a free pooled connection, so lockers can't hang when the pool is exhausted
+const connTimeout = 5 * time.Second
+
+var ErrLockTimeout = errors.New("timed out waiting for lock")
+
+type Locker interface {
+	service.DistributedLocker
+	service.MultiLocker
+	service.LeaseLocker
+}
+
+// NewLocker implements lockers with MySQL named locks. Named locks belong to the session
+// that acquired them, so every Lock or LockMany call keeps its own connection until unlock.
+// Timeout is rounded down to whole seconds, zero fails immediately when the lock is held
+func NewLocker(db *sqlx.DB, timeout time.Duration) Locker {
+	return &locker{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+type locker struct {
+	db      *sqlx.DB
+	timeout time.Duration
+}
+
+func (l *locker) Lock(key string) (func() error, error) {
+	return l.LockMany([]string{key})
+}
+
+func (l *locker) LockMany(keys []string) (func() error, error) {
+	conn, err := l.conn()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		if err = l.acquire(conn, key); err != nil {
+			l.close(conn, l.release(conn, keys[:i]))
+			return nil, err
+		}
+	}
+
+	return func() error {
+		err := l.release(conn, keys)
+		l.close(conn, err)
+		return err
+	}, nil
+}
+
+func (l *locker) LockLease(key string) (service.Lease, error) {
+	conn, err := l.conn()
+	if err != nil {
+		return nil, err
+	}
+	if err = l.acquire(conn, key); err != nil {
+		l.close(conn, nil)
+		return nil, err
+	}
+	return &lease{
+		locker: l,
+		conn:   conn,
+		key:    key,
+	}, nil
+}
+
+type lease struct {
+	locker *locker
+	conn   *sqlx.Conn
+	key    string
+}
+
+// Check fails when MySQL dropped the session holding the lock, the lock is released by MySQL then
+func (l *lease) Check() error {
+	// unbounded check would block the holder forever on a hung database
+	ctx, cancel := context.WithTimeout(context.Background(), connTimeout)
+	defer cancel()
+
+	var held *int
+	err := l.conn.GetContext(ctx, &held, "SELECT IS_USED_LOCK(?) = CONNECTION_ID()", l.key)
+	if err != nil {
+		return err
+	}
+	if held == nil || *held != 1 {
+		return service.ErrLeaseLost
+	}
+	return nil
+}
+
+func (l *lease) Release() error {
+	err := l.locker.release(l.conn, []string{l.key})
+	l.locker.close(l.conn, err)
+	return err
+}
+
+func (l *locker) conn() (*sqlx.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), connTimeout)
+	defer cancel()
+	return l.db.Connx(ctx)
+}
+
+func (l *locker) acquire(conn *sqlx.Conn, key string) error {
+	var acquired *int
+	err := conn.GetContext(context.Background(), &acquired, "SELECT GET_LOCK(?, ?)", key, int(l.timeout/time.Second))
+	if err != nil {
+		return err
+	}
+	if acquired == nil || *acquired != 1 {
+		return ErrLockTimeout
+	}
+	return nil
+}
+
+func (l *locker) release(conn *sqlx.Conn, keys []string) error {
+	for _, key := range keys {
+		if _, err := conn.ExecContext(context.Background(), "DO RELEASE_LOCK(?)", key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close discards connection when locks could not be released, otherwise the pool would hand out a session holding them
+func (l *locker) close(conn *sqlx.Conn, releaseErr error) {
+	if releaseErr != nil {
+		_ = conn.Raw(func(any) error {
+			return driver.ErrBadConn
+		})
+	}
+	_ = conn.Close()
+}
@@ -0,0 +1,143 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+func NewSalesReportRepository(db *sqlx.DB) model.SalesReportRepository {
+	return &salesReportRepository{db: db}
+}
+
+type salesReportRepository struct {
+	db *sqlx.DB
+}
+
+type sqlOrderSales struct {
+	Day     time.Time `db:"day"`
+	Status  int       `db:"status"`
+	Revenue float64   `db:"revenue"`
+}
+
+type sqlSalesRow struct {
+	Day     time.Time `db:"day"`
+	Status  int       `db:"status"`
+	Orders  int       `db:"orders"`
+	Revenue float64   `db:"revenue"`
+}
+
+func (r *salesReportRepository) Record(orderID uuid.UUID, day time.Time, status model.OrderStatus, revenue float64) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = uncount(tx, orderID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO order_sales (order_id, day, status, revenue)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			day = VALUES(day),
+			status = VALUES(status),
+			revenue = VALUES(revenue)
+	`, orderID[:], day.Format(time.DateOnly), int(status), revenue)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO daily_sales (day, status, orders, revenue)
+		VALUES (?, ?, 1, ?)
+		ON DUPLICATE KEY UPDATE
+			orders = orders + 1,
+			revenue = revenue + VALUES(revenue)
+	`, day.Format(time.DateOnly), int(status), revenue)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *salesReportRepository) Remove(orderID uuid.UUID) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	counted, err := uncount(tx, orderID)
+	if err != nil {
+		return err
+	}
+	if counted {
+		if _, err = tx.Exec("DELETE FROM order_sales WHERE order_id = ?", orderID[:]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// uncount takes the order out of the aggregate it was recorded in,
+// the order row is locked, so concurrent updates of one order move it between aggregates one after another
+func uncount(tx *sqlx.Tx, orderID uuid.UUID) (bool, error) {
+	var previous sqlOrderSales
+	err := tx.Get(&previous, "SELECT day, status, revenue FROM order_sales WHERE order_id = ? FOR UPDATE", orderID[:])
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE daily_sales
+		SET orders = orders - 1, revenue = revenue - ?
+		WHERE day = ? AND status = ?
+	`, previous.Revenue, previous.Day.Format(time.DateOnly), previous.Status)
+	return err == nil, err
+}
+
+func (r *salesReportRepository) FindDaily(from, to time.Time) ([]model.SalesRow, error) {
+	const query = `
+		SELECT day, status, orders, revenue
+		FROM daily_sales
+		WHERE day >= ? AND day < ? AND orders > 0
+		ORDER BY day, status
+	`
+
+	var rows []sqlSalesRow
+	if err := r.db.Select(&rows, query, from.Format(time.DateOnly), to.Format(time.DateOnly)); err != nil {
+		return nil, err
+	}
+
+	result := make([]model.SalesRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.SalesRow{
+			Period:  time.Date(row.Day.Year(), row.Day.Month(), row.Day.Day(), 0, 0, 0, 0, time.UTC),
+			Status:  model.OrderStatus(row.Status),
+			Orders:  row.Orders,
+			Revenue: row.Revenue,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const telegramAPIURL = "https://api.telegram.org"
+
+var ErrChatRequestFailed = errors.New("chat request failed")
+
+// NewSlackNotifier posts to an incoming webhook, Message.Recipient is ignored since webhook is bound to a channel
+func NewSlackNotifier(webhookURL string, client *http.Client) Notifier {
+	return &slackNotifier{
+		webhookURL: webhookURL,
+		client:     client,
+	}
+}
+
+type slackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func (n *slackNotifier) Notify(msg Message) error {
+	return postJSON(n.client, n.webhookURL, map[string]string{
+		"text": chatText(msg),
+	})
+}
+
+// NewTelegramNotifier sends messages with a bot to chatID, Message.Recipient overrides chatID when set
+func NewTelegramNotifier(token, chatID string, client *http.Client) Notifier {
+	return &telegramNotifier{
+		apiURL: telegramAPIURL,
+		token:  token,
+		chatID: chatID,
+		client: client,
+	}
+}
+
+type telegramNotifier struct {
+	apiURL string
+	token  string
+	chatID string
+	client *http.Client
+}
+
+func (n *telegramNotifier) Notify(msg Message) error {
+	chatID := n.chatID
+	if msg.Recipient != "" {
+		chatID = msg.Recipient
+	}
+	return postJSON(n.client, n.apiURL+"/bot"+n.token+"/sendMessage", map[string]string{
+		"chat_id": chatID,
+		"text":    chatText(msg),
+	})
+}
+
+func chatText(msg Message) string {
+	if msg.Subject == "" {
+		return msg.Body
+	}
+	return msg.Subject + "\n" + msg.Body
+}
+
+func postJSON(client *http.Client, endpoint string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return send(client, req, ErrChatRequestFailed)
+}
+
+// send wraps transport errors and error statuses with failed
+func send(client *http.Client, req *http.Request, failed error) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		// url.Error carries the endpoint, which may hold a token or a webhook secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("%w: %w", failed, urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: status %d", failed, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type chatRequest struct {
+	path    string
+	payload map[string]string
+	err     error
+}
+
+func TestChatNotifiers(t *testing.T) {
+	requests := make(chan chatRequest, 1)
+	status := make(chan int, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		requests <- chatRequest{path: r.URL.Path, payload: payload, err: err}
+		w.WriteHeader(<-status)
+	}))
+	defer server.Close()
+	msg := Message{Subject: "subject", Body: "body"}
+
+	t.Run("should post to Slack webhook", func(t *testing.T) {
+		status <- http.StatusOK
+		require.NoError(t, NewSlackNotifier(server.URL+"/hook", server.Client()).Notify(msg))
+
+		req := <-requests
+		require.NoError(t, req.err)
+		require.Equal(t, "/hook", req.path)
+		require.Equal(t, map[string]string{"text": "subject\nbody"}, req.payload)
+	})
+
+	t.Run("should send Telegram message", func(t *testing.T) {
+		notifier := NewTelegramNotifier("token", "42", server.Client()).(*telegramNotifier)
+		notifier.apiURL = server.URL
+
+		status <- http.StatusOK
+		require.NoError(t, notifier.Notify(msg))
+
+		req := <-requests
+		require.NoError(t, req.err)
+		require.Equal(t, "/bottoken/sendMessage", req.path)
+		require.Equal(t, map[string]string{"chat_id": "42", "text": "subject\nbody"}, req.payload)
+	})
+
+	t.Run("should not leak Telegram token on transport error", func(t *testing.T) {
+		notifier := NewTelegramNotifier("secret", "42", server.Client()).(*telegramNotifier)
+		notifier.apiURL = "http://127.0.0.1:0"
+
+		err := notifier.Notify(msg)
+		require.ErrorIs(t, err, ErrChatRequestFailed)
+		require.NotContains(t, err.Error(), "secret")
+	})
+
+	t.Run("should fail on error status", func(t *testing.T) {
+		status <- http.StatusBadRequest
+		err := NewSlackNotifier(server.URL, server.Client()).Notify(msg)
+		<-requests
+		require.ErrorIs(t, err, ErrChatRequestFailed)
+	})
+}
+
+func TestOpsNotifications(t *testing.T) {
+	t.Run("should report order crossing value threshold once", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		notifier := &recordingNotifier{}
+		sink := NewHighValueOrderSink(repo, notifier, 100)
+		order := modeltest.NewOrderBuilder().WithItem(uuid.Must(uuid.NewV7()), 60).Build()
+		require.NoError(t, repo.Store(order))
+		require.NoError(t, sink.Dispatch(model.OrderItemsChanged{OrderID: order.ID, AddedItems: []uuid.UUID{order.Items[0].ID}}))
+		require.Empty(t, notifier.messages)
+
+		for range 2 {
+			item := model.Item{ID: uuid.Must(uuid.NewV7()), ProductID: uuid.Must(uuid.NewV7()), Price: 50}
+			order.Items = append(order.Items, item)
+			require.NoError(t, repo.Store(order))
+			require.NoError(t, sink.Dispatch(model.OrderItemsChanged{OrderID: order.ID, AddedItems: []uuid.UUID{item.ID}}))
+		}
+		require.Len(t, notifier.messages, 1)
+		require.Contains(t, notifier.messages[0].Subject, "110.00")
+	})
+
+	t.Run("should report stuck orders and dead letters", func(t *testing.T) {
+		notifier := &recordingNotifier{}
+		orderID := uuid.Must(uuid.NewV7())
+
+		require.NoError(t, NewChatAlerter(notifier).Alert(service.StuckOrdersAlert{
+			Status:    model.Pending,
+			Threshold: time.Hour,
+			OrderIDs:  []uuid.UUID{orderID},
+		}))
+		DeadLetterHandler(notifier, func(err error) { require.NoError(t, err) })(model.OrderDeleted{OrderID: orderID}, errSend)
+
+		require.Len(t, notifier.messages, 2)
+		require.Equal(t, "1 orders stuck in pending for more than 1h0m0s", notifier.messages[0].Subject)
+		require.Equal(t, orderID.String(), notifier.messages[0].Body)
+		require.Equal(t, "Event OrderDeleted was not dispatched", notifier.messages[1].Subject)
+	})
+
+	t.Run("should drop dead letter notify errors without handler", func(t *testing.T) {
+		notifier := &recordingNotifier{err: errSend}
+		require.NotPanics(t, func() {
+			DeadLetterHandler(notifier, nil)(model.OrderDeleted{OrderID: uuid.Must(uuid.NewV7())}, errSend)
+		})
+	})
+}
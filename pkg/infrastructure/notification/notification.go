@@ -0,0 +1,160 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+)
+
+type Message struct {
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+type Notifier interface {
+	Notify(msg Message) error
+}
+
+// Preferences maps channels the customer opted in to recipient addresses on them
+type Preferences map[Channel]string
+
+type PreferenceLookup interface {
+	Preferences(customerID uuid.UUID) (Preferences, error)
+}
+
+type Template struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// NewTemplate parses text templates, they are executed with TemplateData
+func NewTemplate(subject, body string) (Template, error) {
+	subjectTemplate, err := template.New("subject").Parse(subject)
+	if err != nil {
+		return Template{}, err
+	}
+	bodyTemplate, err := template.New("body").Parse(body)
+	if err != nil {
+		return Template{}, err
+	}
+	return Template{subject: subjectTemplate, body: bodyTemplate}, nil
+}
+
+type TemplateData struct {
+	Order *model.Order
+	Event service.Event
+}
+
+// DefaultTemplates returns templates keyed by event type. Status comes from the event,
+// the order may have changed since the event was queued
+func DefaultTemplates() map[string]Template {
+	return map[string]Template{
+		model.OrderCreated{}.Type(): mustTemplate(
+			"Order {{.Order.ID}} created",
+			"Your order {{.Order.ID}} has been created.",
+		),
+		model.OrderStatusChanged{}.Type(): mustTemplate(
+			"Order {{.Order.ID}} is {{.Event.NewStatus}}",
+			"Your order {{.Order.ID}} is now {{.Event.NewStatus}}.",
+		),
+	}
+}
+
+func mustTemplate(subject, body string) Template {
+	t, err := NewTemplate(subject, body)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// NewEventSink notifies customers about events having a template, other events are ignored.
+// Channels without a notifier are skipped
+func NewEventSink(
+	repo model.OrderRepository,
+	preferences PreferenceLookup,
+	notifiers map[Channel]Notifier,
+	templates map[string]Template,
+) service.EventDispatcher {
+	return &eventSink{
+		repo:        repo,
+		preferences: preferences,
+		notifiers:   notifiers,
+		templates:   templates,
+	}
+}
+
+type eventSink struct {
+	repo        model.OrderRepository
+	preferences PreferenceLookup
+	notifiers   map[Channel]Notifier
+	templates   map[string]Template
+}
+
+type aggregateEvent interface {
+	AggregateID() uuid.UUID
+}
+
+func (s *eventSink) Dispatch(event service.Event) error {
+	tmpl, ok := s.templates[event.Type()]
+	if !ok {
+		return nil
+	}
+	aggregate, ok := event.(aggregateEvent)
+	if !ok {
+		return nil
+	}
+
+	order, err := s.repo.Find(aggregate.AggregateID())
+	if err != nil {
+		return err
+	}
+	preferences, err := s.preferences.Preferences(order.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	data := TemplateData{Order: order, Event: event}
+	subject, err := render(tmpl.subject, data)
+	if err != nil {
+		return err
+	}
+	body, err := render(tmpl.body, data)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for channel, recipient := range preferences {
+		notifier, ok := s.notifiers[channel]
+		if !ok || recipient == "" {
+			continue
+		}
+		if err = notifier.Notify(Message{Recipient: recipient, Subject: subject, Body: body}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func render(t *template.Template, data TemplateData) (string, error) {
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+)
+
+var errSend = errors.New("send failed")
+
+type recordingNotifier struct {
+	messages []Message
+	err      error
+}
+
+func (n *recordingNotifier) Notify(msg Message) error {
+	n.messages = append(n.messages, msg)
+	return n.err
+}
+
+type staticPreferences map[uuid.UUID]Preferences
+
+func (p staticPreferences) Preferences(customerID uuid.UUID) (Preferences, error) {
+	return p[customerID], nil
+}
+
+func TestEventSink(t *testing.T) {
+	order := modeltest.NewOrderBuilder().WithStatus(model.Paid).Build()
+	repo := modeltest.NewFakeOrderRepository()
+	require.NoError(t, repo.Store(order))
+	preferences := staticPreferences{order.CustomerID: {ChannelEmail: "customer@example.com", ChannelSMS: "+100000"}}
+
+	t.Run("should render template for opted in channels", func(t *testing.T) {
+		email := &recordingNotifier{}
+		sink := NewEventSink(repo, preferences, map[Channel]Notifier{ChannelEmail: email}, DefaultTemplates())
+
+		require.NoError(t, sink.Dispatch(model.OrderStatusChanged{OrderID: order.ID, NewStatus: model.Paid}))
+		require.Equal(t, []Message{{
+			Recipient: "customer@example.com",
+			Subject:   "Order " + order.ID.String() + " is paid",
+			Body:      "Your order " + order.ID.String() + " is now paid.",
+		}}, email.messages)
+	})
+
+	t.Run("should render status of the event rather than current status", func(t *testing.T) {
+		email := &recordingNotifier{}
+		sink := NewEventSink(repo, preferences, map[Channel]Notifier{ChannelEmail: email}, DefaultTemplates())
+
+		require.NoError(t, sink.Dispatch(model.OrderStatusChanged{OrderID: order.ID, NewStatus: model.Pending}))
+		require.Len(t, email.messages, 1)
+		require.Equal(t, "Order "+order.ID.String()+" is pending", email.messages[0].Subject)
+	})
+
+	t.Run("should ignore events without template and customers without preferences", func(t *testing.T) {
+		email := &recordingNotifier{}
+		sink := NewEventSink(repo, staticPreferences{}, map[Channel]Notifier{ChannelEmail: email}, DefaultTemplates())
+
+		require.NoError(t, sink.Dispatch(model.OrderDeleted{OrderID: order.ID}))
+		require.NoError(t, sink.Dispatch(model.OrderCreated{OrderID: order.ID, CustomerID: order.CustomerID}))
+		require.Empty(t, email.messages)
+	})
+
+	t.Run("should report failed channels", func(t *testing.T) {
+		sms := &recordingNotifier{err: errSend}
+		sink := NewEventSink(repo, preferences, map[Channel]Notifier{ChannelSMS: sms}, DefaultTemplates())
+
+		err := sink.Dispatch(model.OrderCreated{OrderID: order.ID, CustomerID: order.CustomerID})
+		require.ErrorIs(t, err, errSend)
+		require.Len(t, sms.messages, 1)
+	})
+}
@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+// NewChatAlerter reports stuck orders to ops chat
+func NewChatAlerter(notifier Notifier) service.Alerter {
+	return &chatAlerter{notifier: notifier}
+}
+
+type chatAlerter struct {
+	notifier Notifier
+}
+
+func (a *chatAlerter) Alert(alert service.StuckOrdersAlert) error {
+	ids := make([]string, 0, len(alert.OrderIDs))
+	for _, id := range alert.OrderIDs {
+		ids = append(ids, id.String())
+	}
+	return a.notifier.Notify(Message{
+		Subject: fmt.Sprintf("%d orders stuck in %s for more than %s", len(alert.OrderIDs), alert.Status, alert.Threshold),
+		Body:    strings.Join(ids, "\n"),
+	})
+}
+
+// DeadLetterHandler reports events the async dispatcher gave up on, it fits dispatcher.WithErrorHandler.
+// Notify errors go to onError, nil onError drops them
+func DeadLetterHandler(notifier Notifier, onError func(error)) func(event service.Event, err error) {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	return func(event service.Event, err error) {
+		notifyErr := notifier.Notify(Message{
+			Subject: "Event " + event.Type() + " was not dispatched",
+			Body:    fmt.Sprintf("%+v\n%v", event, err),
+		})
+		if notifyErr != nil {
+			onError(notifyErr)
+		}
+	}
+}
+
+// NewHighValueOrderSink reports orders once their total reaches threshold
+func NewHighValueOrderSink(repo model.OrderRepository, notifier Notifier, threshold float64) service.EventDispatcher {
+	return &highValueOrderSink{
+		repo:      repo,
+		notifier:  notifier,
+		threshold: threshold,
+	}
+}
+
+type highValueOrderSink struct {
+	repo      model.OrderRepository
+	notifier  Notifier
+	threshold float64
+}
+
+func (s *highValueOrderSink) Dispatch(event service.Event) error {
+	changed, ok := event.(model.OrderItemsChanged)
+	if !ok || len(changed.AddedItems) == 0 {
+		return nil
+	}
+
+	order, err := s.repo.Find(changed.OrderID)
+	if err != nil {
+		return err
+	}
+
+	added := make(map[uuid.UUID]struct{}, len(changed.AddedItems))
+	for _, id := range changed.AddedItems {
+		added[id] = struct{}{}
+	}
+	var total, addedTotal float64
+	for _, item := range order.Items {
+		total += item.Price
+		if _, ok := added[item.ID]; ok {
+			addedTotal += item.Price
+		}
+	}
+	// only the change crossing the threshold is reported
+	if total < s.threshold || total-addedTotal >= s.threshold {
+		return nil
+	}
+
+	return s.notifier.Notify(Message{
+		Subject: fmt.Sprintf("Order %s total reached %.2f", order.ID, total),
+		Body:    fmt.Sprintf("customer %s, %d items", order.CustomerID, len(order.Items)),
+	})
+}
@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const twilioAPIURL = "https://api.twilio.com"
+
+var ErrSMSRequestFailed = errors.New("sms request failed")
+
+// NewTwilioNotifier sends text messages from the from number with Twilio Messages API,
+// Message.Recipient is a phone number in E.164 format
+func NewTwilioNotifier(accountSID, authToken, from string, client *http.Client) Notifier {
+	return &twilioNotifier{
+		apiURL:     twilioAPIURL,
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     client,
+	}
+}
+
+type twilioNotifier struct {
+	apiURL     string
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+func (n *twilioNotifier) Notify(msg Message) error {
+	form := url.Values{
+		"To":   {msg.Recipient},
+		"From": {n.from},
+		"Body": {chatText(msg)},
+	}
+	endpoint := n.apiURL + "/2010-04-01/Accounts/" + url.PathEscape(n.accountSID) + "/Messages.json"
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(n.accountSID, n.authToken)
+	return send(n.client, req, ErrSMSRequestFailed)
+}
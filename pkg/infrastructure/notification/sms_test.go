@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type smsRequest struct {
+	path     string
+	user     string
+	password string
+	form     url.Values
+	err      error
+}
+
+func TestTwilioNotifier(t *testing.T) {
+	requests := make(chan smsRequest, 1)
+	status := make(chan int, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		err := r.ParseForm()
+		requests <- smsRequest{path: r.URL.Path, user: user, password: password, form: r.PostForm, err: err}
+		w.WriteHeader(<-status)
+	}))
+	defer server.Close()
+
+	newNotifier := func() Notifier {
+		notifier := NewTwilioNotifier("AC42", "secret", "+15550000000", server.Client()).(*twilioNotifier)
+		notifier.apiURL = server.URL
+		return notifier
+	}
+
+	t.Run("should send message to recipient", func(t *testing.T) {
+		status <- http.StatusCreated
+		require.NoError(t, newNotifier().Notify(Message{Recipient: "+15551111111", Subject: "subject", Body: "body"}))
+
+		req := <-requests
+		require.NoError(t, req.err)
+		require.Equal(t, "/2010-04-01/Accounts/AC42/Messages.json", req.path)
+		require.Equal(t, "AC42", req.user)
+		require.Equal(t, "secret", req.password)
+		require.Equal(t, url.Values{
+			"To":   {"+15551111111"},
+			"From": {"+15550000000"},
+			"Body": {"subject\nbody"},
+		}, req.form)
+	})
+
+	t.Run("should fail on error status", func(t *testing.T) {
+		status <- http.StatusBadRequest
+		err := newNotifier().Notify(Message{Recipient: "+15551111111", Body: "body"})
+		<-requests
+		require.ErrorIs(t, err, ErrSMSRequestFailed)
+	})
+}
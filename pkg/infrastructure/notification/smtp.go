@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"errors"
+	"net/smtp"
+	"strings"
+)
+
+var ErrInvalidHeader = errors.New("line break in email header")
+
+// NewSMTPNotifier sends plain text emails, auth may be nil for relays without authentication
+func NewSMTPNotifier(address, from string, auth smtp.Auth) Notifier {
+	return &smtpNotifier{
+		address: address,
+		from:    from,
+		auth:    auth,
+	}
+}
+
+type smtpNotifier struct {
+	address string
+	from    string
+	auth    smtp.Auth
+}
+
+func (n *smtpNotifier) Notify(msg Message) error {
+	if strings.ContainsAny(msg.Recipient+msg.Subject, "\r\n") {
+		return ErrInvalidHeader
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + n.from + "\r\n")
+	b.WriteString("To: " + msg.Recipient + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	return smtp.SendMail(n.address, n.auth, n.from, []string{msg.Recipient}, []byte(b.String()))
+}
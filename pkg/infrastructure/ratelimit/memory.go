@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+// NewMemoryStore keeps counters of a single replica, use a shared store to limit across replicas
+func NewMemoryStore() service.RateLimitStore {
+	return &memoryStore{
+		counters: make(map[string]*counter),
+	}
+}
+
+type counter struct {
+	windowStart time.Time
+	expiresAt   time.Time
+	value       int64
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep int
+}
+
+func (s *memoryStore) Increment(key string, windowStart time.Time, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok || !c.windowStart.Equal(windowStart) {
+		s.sweep(windowStart)
+		c = &counter{
+			windowStart: windowStart,
+			expiresAt:   windowStart.Add(window),
+		}
+		s.counters[key] = c
+	}
+	c.value++
+	return c.value, nil
+}
+
+// sweep drops expired counters once number of counters doubles, so cost is amortized
+func (s *memoryStore) sweep(now time.Time) {
+	if len(s.counters) < 2*s.lastSweep {
+		return
+	}
+	for key, c := range s.counters {
+		if !c.expiresAt.After(now) {
+			delete(s.counters, key)
+		}
+	}
+	s.lastSweep = max(len(s.counters), 1)
+}
@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Run("should count per key and window", func(t *testing.T) {
+		store := NewMemoryStore()
+		window := time.Minute
+		start := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+
+		for i := range 3 {
+			count, err := store.Increment("a", start, window)
+			require.NoError(t, err)
+			require.Equal(t, int64(i+1), count)
+		}
+		count, err := store.Increment("b", start, window)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+
+		count, err = store.Increment("a", start.Add(window), window)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+	})
+
+	t.Run("should drop expired counters", func(t *testing.T) {
+		store := NewMemoryStore().(*memoryStore)
+		start := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+		for _, key := range []string{"a", "b", "c", "d"} {
+			_, err := store.Increment(key, start, time.Minute)
+			require.NoError(t, err)
+		}
+
+		_, err := store.Increment("a", start.Add(time.Hour), time.Minute)
+		require.NoError(t, err)
+		require.Len(t, store.counters, 1)
+	})
+}
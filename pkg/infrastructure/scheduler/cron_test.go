@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron(t *testing.T) {
+	// 2025-01-06 is Monday
+	monday := time.Date(2025, 1, 6, 10, 7, 30, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		expr  string
+		after time.Time
+		next  time.Time
+	}{
+		{"every minute", "* * * * *", monday, time.Date(2025, 1, 6, 10, 8, 0, 0, time.UTC)},
+		{"step", "*/15 * * * *", monday, time.Date(2025, 1, 6, 10, 15, 0, 0, time.UTC)},
+		{"after matching minute", "15 * * * *", time.Date(2025, 1, 6, 10, 15, 0, 0, time.UTC), time.Date(2025, 1, 6, 11, 15, 0, 0, time.UTC)},
+		{"nightly", "0 3 * * *", monday, time.Date(2025, 1, 7, 3, 0, 0, 0, time.UTC)},
+		{"list and range", "30 9-17 * * 1,3", time.Date(2025, 1, 6, 17, 45, 0, 0, time.UTC), time.Date(2025, 1, 8, 9, 30, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", monday, time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"range step", "0 0 1-31/10 * *", monday, time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"day of month or weekday", "0 0 20 * 5", monday, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{"next year", "0 0 1 1 *", monday, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", monday, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", monday, time.Time{}},
+		{"in UTC", "0 12 * * *", time.Date(2025, 1, 6, 12, 30, 0, 0, time.FixedZone("UTC+1", 3600)), time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cron, err := parseCron(tc.expr)
+			require.NoError(t, err)
+			require.Equal(t, tc.next, cron.next(tc.after))
+		})
+	}
+
+	t.Run("should reject invalid expressions", func(t *testing.T) {
+		for _, expr := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+			_, err := parseCron(expr)
+			require.ErrorIs(t, err, ErrInvalidCron, expr)
+		}
+	})
+}
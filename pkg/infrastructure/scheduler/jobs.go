@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+// ArchiveJob moves final orders not updated for olderThan to the archive on cron schedule
+func ArchiveJob(cron string, archiver service.OrderArchiver, olderThan time.Duration) Job {
+	return Job{
+		Name: "archive",
+		Cron: cron,
+		Run: func() error {
+			_, err := archiver.Archive(olderThan)
+			return err
+		},
+	}
+}
+
+// StuckOrdersJob alerts about stuck orders on cron schedule
+func StuckOrdersJob(cron string, monitor service.StuckOrderMonitor) Job {
+	return Job{
+		Name: "stuck-orders",
+		Cron: cron,
+		Run:  monitor.Check,
+	}
+}
@@ -0,0 +1,202 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/metrics"
+)
+
+const (
+	jobRunsMetric     = "scheduler.job.runs"
+	jobDurationMetric = "scheduler.job.duration"
+	jobLeaderMetric   = "scheduler.job.leader"
+)
+
+var (
+	ErrInvalidInterval = errors.New("job interval must be positive")
+	ErrInvalidCron     = errors.New("invalid cron expression")
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// Cron is a cron expression in UTC, see parseCron, it is used instead of Interval when set
+	Cron string
+	Run  func() error
+}
+
+type Option func(o *options)
+
+func WithMetrics(recorder metrics.Recorder) Option {
+	return func(o *options) {
+		o.recorder = recorder
+	}
+}
+
+// WithErrorHandler receives errors of job runs, of lease checks and of leadership release
+func WithErrorHandler(handler func(job string, err error)) Option {
+	return func(o *options) {
+		o.onError = handler
+	}
+}
+
+type options struct {
+	recorder metrics.Recorder
+	onError  func(job string, err error)
+}
+
+type Scheduler interface {
+	// Add fails with ErrInvalidInterval or ErrInvalidCron when the job can't be scheduled
+	Add(job Job) error
+	// Run runs jobs on their schedules until ctx is done. Each job runs only on the replica that holds its lock,
+	// other replicas try to take the lock over on every tick, so the job moves when the leader goes away.
+	// The leader checks its lease before every run and gives leadership up when the lease can't be confirmed
+	Run(ctx context.Context)
+}
+
+// NewScheduler uses locker for leader election, locker must fail fast when the lock is held by another replica
+func NewScheduler(locker service.LeaseLocker, opts ...Option) Scheduler {
+	o := options{
+		recorder: metrics.NewNopRecorder(),
+		onError:  func(string, error) {},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &scheduler{
+		locker:  locker,
+		options: o,
+	}
+}
+
+type scheduler struct {
+	locker  service.LeaseLocker
+	options options
+	jobs    []scheduledJob
+}
+
+type scheduledJob struct {
+	Job
+	// next returns the time of the run following after, zero time means there are no more runs
+	next func(after time.Time) time.Time
+}
+
+func (s *scheduler) Add(job Job) error {
+	scheduled := scheduledJob{Job: job}
+	switch {
+	case job.Cron != "":
+		cron, err := parseCron(job.Cron)
+		if err != nil {
+			return err
+		}
+		if cron.next(time.Now()).IsZero() {
+			return fmt.Errorf("%w: %q never matches", ErrInvalidCron, job.Cron)
+		}
+		scheduled.next = cron.next
+	case job.Interval > 0:
+		scheduled.next = func(after time.Time) time.Time {
+			return after.Add(job.Interval)
+		}
+	default:
+		return ErrInvalidInterval
+	}
+
+	s.jobs = append(s.jobs, scheduled)
+	return nil
+}
+
+func (s *scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runJob(ctx, job)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *scheduler) runJob(ctx context.Context, job scheduledJob) {
+	var lease service.Lease
+	defer func() {
+		if lease != nil {
+			s.release(job.Job, lease)
+		}
+	}()
+
+	tags := metrics.Tags{"job": job.Name}
+	for {
+		if !wait(ctx, job.next(time.Now())) {
+			return
+		}
+
+		// the lock may be lost without unlock, e.g. MySQL dropped the session, and taken by another replica
+		if lease != nil {
+			if err := lease.Check(); err != nil {
+				s.options.onError(job.Name, err)
+				s.release(job.Job, lease)
+				lease = nil
+				s.options.recorder.SetGauge(jobLeaderMetric, 0, tags)
+			}
+		}
+
+		if lease == nil {
+			var err error
+			// failed lock means another replica is the leader
+			if lease, err = s.locker.LockLease("scheduler:" + job.Name); err != nil {
+				lease = nil
+				s.options.recorder.SetGauge(jobLeaderMetric, 0, tags)
+				continue
+			}
+			s.options.recorder.SetGauge(jobLeaderMetric, 1, tags)
+			// the lock may be released by the old leader only because ctx is done
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		s.run(job.Job)
+	}
+}
+
+// wait reports whether next came before ctx is done
+func wait(ctx context.Context, next time.Time) bool {
+	if next.IsZero() {
+		return false
+	}
+	timer := time.NewTimer(time.Until(next))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+	}
+	// select picks randomly when both are ready, don't take leadership while shutting down
+	return ctx.Err() == nil
+}
+
+func (s *scheduler) release(job Job, lease service.Lease) {
+	if err := lease.Release(); err != nil {
+		s.options.onError(job.Name, err)
+	}
+}
+
+func (s *scheduler) run(job Job) {
+	start := time.Now()
+	err := job.Run()
+
+	result := "success"
+	if err != nil {
+		result = "error"
+		s.options.onError(job.Name, err)
+	}
+	s.options.recorder.IncCounter(jobRunsMetric, metrics.Tags{"job": job.Name, "result": result})
+	s.options.recorder.ObserveDuration(jobDurationMetric, time.Since(start), metrics.Tags{"job": job.Name})
+}
@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+var (
+	errLocked = errors.New("locked")
+	errJob    = errors.New("job failed")
+)
+
+type memoryLocker struct {
+	mu     sync.Mutex
+	holder map[string]*memoryLease
+}
+
+type memoryLease struct {
+	locker *memoryLocker
+	key    string
+}
+
+func (l *memoryLocker) LockLease(key string) (service.Lease, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder[key] != nil {
+		return nil, errLocked
+	}
+	lease := &memoryLease{locker: l, key: key}
+	l.holder[key] = lease
+	return lease, nil
+}
+
+// steal hands the lock to another holder without the current one releasing it
+func (l *memoryLocker) steal(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.holder[key] = &memoryLease{locker: l, key: key}
+}
+
+func (l *memoryLease) Check() error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	if l.locker.holder[l.key] != l {
+		return service.ErrLeaseLost
+	}
+	return nil
+}
+
+func (l *memoryLease) Release() error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	if l.locker.holder[l.key] == l {
+		delete(l.locker.holder, l.key)
+	}
+	return nil
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("should run every job on one replica only", func(t *testing.T) {
+		locker := &memoryLocker{holder: map[string]*memoryLease{}}
+		var runs [2]atomic.Int64
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var wg sync.WaitGroup
+		for replica := range runs {
+			s := NewScheduler(locker)
+			require.NoError(t, s.Add(Job{Name: "archive", Interval: time.Millisecond, Run: func() error {
+				runs[replica].Add(1)
+				return nil
+			}}))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.Run(ctx)
+			}()
+		}
+
+		require.Eventually(t, func() bool {
+			return runs[0].Load()+runs[1].Load() >= 20
+		}, time.Second, time.Millisecond)
+		cancel()
+		wg.Wait()
+
+		require.True(t, runs[0].Load() == 0 || runs[1].Load() == 0)
+		require.Empty(t, locker.holder)
+	})
+
+	t.Run("should take over when leader stops", func(t *testing.T) {
+		locker := &memoryLocker{holder: map[string]*memoryLease{}}
+		leaderCtx, stopLeader := context.WithCancel(context.Background())
+		var leaderRuns, followerRuns atomic.Int64
+
+		leader := NewScheduler(locker)
+		require.NoError(t, leader.Add(Job{Name: "monitor", Interval: time.Millisecond, Run: func() error {
+			leaderRuns.Add(1)
+			return nil
+		}}))
+		leaderDone := make(chan struct{})
+		go func() {
+			leader.Run(leaderCtx)
+			close(leaderDone)
+		}()
+		require.Eventually(t, func() bool { return leaderRuns.Load() > 0 }, time.Second, time.Millisecond)
+
+		followerCtx, stopFollower := context.WithCancel(context.Background())
+		defer stopFollower()
+		follower := NewScheduler(locker)
+		require.NoError(t, follower.Add(Job{Name: "monitor", Interval: time.Millisecond, Run: func() error {
+			followerRuns.Add(1)
+			return nil
+		}}))
+		go follower.Run(followerCtx)
+
+		time.Sleep(10 * time.Millisecond)
+		require.Zero(t, followerRuns.Load())
+
+		stopLeader()
+		<-leaderDone
+		require.Eventually(t, func() bool { return followerRuns.Load() > 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should report job errors and keep running", func(t *testing.T) {
+		var failures atomic.Int64
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s := NewScheduler(&memoryLocker{holder: map[string]*memoryLease{}}, WithErrorHandler(func(job string, err error) {
+			if job == "purge" && errors.Is(err, errJob) {
+				failures.Add(1)
+			}
+		}))
+		require.NoError(t, s.Add(Job{Name: "purge", Interval: time.Millisecond, Run: func() error {
+			return errJob
+		}}))
+		go s.Run(ctx)
+
+		require.Eventually(t, func() bool { return failures.Load() >= 3 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should drop leadership when lease is lost", func(t *testing.T) {
+		locker := &memoryLocker{holder: map[string]*memoryLease{}}
+		var runs, lost atomic.Int64
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s := NewScheduler(locker, WithErrorHandler(func(_ string, err error) {
+			if errors.Is(err, service.ErrLeaseLost) {
+				lost.Add(1)
+			}
+		}))
+		require.NoError(t, s.Add(Job{Name: "archive", Interval: time.Millisecond, Run: func() error {
+			runs.Add(1)
+			return nil
+		}}))
+		go s.Run(ctx)
+		require.Eventually(t, func() bool { return runs.Load() > 0 }, time.Second, time.Millisecond)
+
+		locker.steal("scheduler:archive")
+		require.Eventually(t, func() bool { return lost.Load() > 0 }, time.Second, time.Millisecond)
+
+		stopped := runs.Load()
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(t, stopped, runs.Load())
+		require.Equal(t, int64(1), lost.Load())
+	})
+
+	t.Run("should reject jobs without valid schedule", func(t *testing.T) {
+		s := NewScheduler(&memoryLocker{holder: map[string]*memoryLease{}})
+		run := func() error { return nil }
+
+		require.ErrorIs(t, s.Add(Job{Name: "zero", Run: run}), ErrInvalidInterval)
+		require.ErrorIs(t, s.Add(Job{Name: "negative", Interval: -time.Second, Run: run}), ErrInvalidInterval)
+		require.ErrorIs(t, s.Add(Job{Name: "cron", Cron: "61 * * * *", Run: run}), ErrInvalidCron)
+		require.ErrorIs(t, s.Add(Job{Name: "never", Cron: "0 0 30 2 *", Run: run}), ErrInvalidCron)
+		require.NoError(t, s.Add(Job{Name: "nightly", Cron: "0 3 * * *", Run: run}))
+	})
+}
+
+type stubArchiver struct {
+	service.OrderArchiver
+	olderThan time.Duration
+}
+
+func (a *stubArchiver) Archive(olderThan time.Duration) (int, error) {
+	a.olderThan = olderThan
+	return 0, nil
+}
+
+func TestMaintenanceJobs(t *testing.T) {
+	s := NewScheduler(&memoryLocker{holder: map[string]*memoryLease{}})
+
+	t.Run("should archive orders older than threshold", func(t *testing.T) {
+		archiver := &stubArchiver{}
+		job := ArchiveJob("0 3 * * *", archiver, 30*24*time.Hour)
+		require.NoError(t, s.Add(job))
+
+		require.NoError(t, job.Run())
+		require.Equal(t, 30*24*time.Hour, archiver.olderThan)
+	})
+
+	t.Run("should check stuck orders", func(t *testing.T) {
+		repo := modeltest.NewFakeOrderRepository()
+		monitor := service.NewStuckOrderMonitor(repo, nil, map[model.OrderStatus]time.Duration{model.Pending: time.Hour})
+		job := StuckOrdersJob("*/5 * * * *", monitor)
+		require.NoError(t, s.Add(job))
+
+		require.NoError(t, job.Run())
+		require.Equal(t, 1, repo.CallCount("FindStuck"))
+	})
+}
@@ -2,63 +2,92 @@ package transport
 
 import (
 	"context"
+	"net/http"
 
-	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
-)
 
-type errorSet map[error]struct{}
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
 
-func newErrorSet(errs ...error) errorSet {
-	s := make(errorSet)
-	for _, err := range errs {
-		s[err] = struct{}{}
-	}
-	return s
+// errorKind describes how a domain error is exposed by every transport
+type errorKind struct {
+	code   codes.Code
+	reason string
 }
 
-func (s errorSet) Has(err error) bool {
-	_, ok := s[err]
-	return ok
+var domainErrors = map[error]errorKind{
+	model.ErrOrderNotFound:           {code: codes.NotFound, reason: "ORDER_NOT_FOUND"},
+	model.ErrCustomerStatsNotFound:   {code: codes.NotFound, reason: "CUSTOMER_STATS_NOT_FOUND"},
+	model.ErrUnknownOrderStatus:      {code: codes.InvalidArgument, reason: "UNKNOWN_ORDER_STATUS"},
+	service.ErrItemNotFound:          {code: codes.NotFound, reason: "ITEM_NOT_FOUND"},
+	service.ErrInvalidOrderStatus:    {code: codes.FailedPrecondition, reason: "INVALID_ORDER_STATUS"},
+	service.ErrItemsLimitExceeded:    {code: codes.FailedPrecondition, reason: "ITEMS_LIMIT_EXCEEDED"},
+	service.ErrTagNotFound:           {code: codes.NotFound, reason: "TAG_NOT_FOUND"},
+	service.ErrTagsLimitExceeded:     {code: codes.FailedPrecondition, reason: "TAGS_LIMIT_EXCEEDED"},
+	model.ErrInvalidTag:              {code: codes.InvalidArgument, reason: "INVALID_TAG"},
+	model.ErrOpenOrdersLimitExceeded: {code: codes.FailedPrecondition, reason: "OPEN_ORDERS_LIMIT_EXCEEDED"},
+	service.ErrRateLimited:           {code: codes.ResourceExhausted, reason: "RATE_LIMITED"},
+	service.ErrInvalidCustomerID:     {code: codes.InvalidArgument, reason: "INVALID_CUSTOMER_ID"},
+	ErrInvalidOrderMessage:           {code: codes.InvalidArgument, reason: "INVALID_ORDER_MESSAGE"},
+	context.DeadlineExceeded:         {code: codes.DeadlineExceeded, reason: "DEADLINE_EXCEEDED"},
+	context.Canceled:                 {code: codes.Canceled, reason: "CANCELED"},
 }
 
-var badRequestErrorCodes = newErrorSet()
-
-var notFoundErrorCodes = newErrorSet()
+var unknownErrorKind = errorKind{code: codes.Unknown, reason: "UNKNOWN"}
+
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
 
-var unauthorizedErrorCodes = newErrorSet()
+// getErrorKind recursively unwraps wrapped and joined errors and returns kind of the first meaningful error
+func getErrorKind(err error) (errorKind, bool) {
+	if err == nil {
+		return errorKind{code: codes.OK}, true
+	}
+	if kind, ok := domainErrors[err]; ok {
+		return kind, true
+	}
 
-var permissionDeniedErrorCodes = newErrorSet()
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if kind, ok := getErrorKind(inner); ok {
+				return kind, true
+			}
+		}
+	case interface{ Unwrap() error }:
+		return getErrorKind(e.Unwrap())
+	}
 
-var internalErrorCodes = newErrorSet()
+	return unknownErrorKind, false
+}
 
-// getGRPCCode recursively unwraps joined errors and returns GRPC code by the first meaningful error
 func getGRPCCode(err error) codes.Code {
-	cause := errors.Cause(err)
-
-	switch {
-	case cause == nil:
-		return codes.OK
-	case isBadRequestError(cause):
-		return codes.InvalidArgument
-	case isNotFoundError(cause):
-		return codes.NotFound
-	case isUnauthorizedError(cause):
-		return codes.Unauthenticated
-	case isPermissionDeniedError(cause):
-		return codes.PermissionDenied
-	case isInternalError(cause):
-		return codes.Internal
-	}
+	kind, _ := getErrorKind(err)
+	return kind.code
+}
 
-	switch cause {
-	case context.DeadlineExceeded:
-		return codes.DeadlineExceeded
-	case context.Canceled:
-		return codes.Canceled
-	default:
-		return codes.Unknown
+func getHTTPStatus(err error) int {
+	status, ok := httpStatuses[getGRPCCode(err)]
+	if !ok {
+		return http.StatusInternalServerError
 	}
+	return status
 }
 
 func isWarnLevel(err error) bool {
@@ -69,6 +98,7 @@ func isWarnLevel(err error) bool {
 		codes.InvalidArgument,
 		codes.NotFound,
 		codes.FailedPrecondition,
+		codes.ResourceExhausted,
 		codes.Unauthenticated:
 		return true
 	default:
@@ -76,22 +106,34 @@ func isWarnLevel(err error) bool {
 	}
 }
 
-func isBadRequestError(cause error) bool {
-	return badRequestErrorCodes.Has(cause)
+// Problem is an RFC 9457 problem details body for HTTP transports
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   string `json:"code"`
 }
 
-func isNotFoundError(cause error) bool {
-	return notFoundErrorCodes.Has(cause)
-}
+const ProblemContentType = "application/problem+json"
 
-func isUnauthorizedError(cause error) bool {
-	return unauthorizedErrorCodes.Has(cause)
-}
+func NewProblem(err error) Problem {
+	kind, _ := getErrorKind(err)
+	status := getHTTPStatus(err)
 
-func isPermissionDeniedError(cause error) bool {
-	return permissionDeniedErrorCodes.Has(cause)
-}
+	title := http.StatusText(status)
+	if title == "" {
+		title = kind.reason
+	}
 
-func isInternalError(cause error) bool {
-	return internalErrorCodes.Has(cause)
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  title,
+		Status: status,
+		Code:   kind.reason,
+	}
+	if err != nil && kind.code != codes.Unknown {
+		problem.Detail = err.Error()
+	}
+	return problem
 }
@@ -0,0 +1,60 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/service"
+)
+
+func TestErrorMapping(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		code       codes.Code
+		httpStatus int
+		reason     string
+	}{
+		{"nil", nil, codes.OK, http.StatusOK, ""},
+		{"order not found", model.ErrOrderNotFound, codes.NotFound, http.StatusNotFound, "ORDER_NOT_FOUND"},
+		{"wrapped with fmt", fmt.Errorf("find: %w", service.ErrItemNotFound), codes.NotFound, http.StatusNotFound, "ITEM_NOT_FOUND"},
+		{"wrapped with pkg/errors", pkgerrors.Wrap(service.ErrInvalidOrderStatus, "set status"), codes.FailedPrecondition, http.StatusConflict, "INVALID_ORDER_STATUS"},
+		{"unknown order status", fmt.Errorf("%w: %q", model.ErrUnknownOrderStatus, "shipped"), codes.InvalidArgument, http.StatusBadRequest, "UNKNOWN_ORDER_STATUS"},
+		{"joined", errors.Join(errors.New("plain"), context.DeadlineExceeded), codes.DeadlineExceeded, http.StatusGatewayTimeout, "DEADLINE_EXCEEDED"},
+		{"unknown", errors.New("plain"), codes.Unknown, http.StatusInternalServerError, "UNKNOWN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.code, getGRPCCode(tc.err))
+			require.Equal(t, tc.httpStatus, getHTTPStatus(tc.err))
+			if tc.err == nil {
+				return
+			}
+
+			problem := NewProblem(tc.err)
+			require.Equal(t, tc.httpStatus, problem.Status)
+			require.Equal(t, tc.reason, problem.Code)
+
+			st, ok := status.FromError(ErrorInterceptor{}.TranslateGRPCError(tc.err))
+			require.True(t, ok)
+			require.Equal(t, tc.code, st.Code())
+			require.Len(t, st.Details(), 1)
+			require.Equal(t, tc.reason, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+		})
+	}
+
+	t.Run("should hide details of unknown errors in problem", func(t *testing.T) {
+		require.Empty(t, NewProblem(errors.New("sql: connection refused")).Detail)
+	})
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 
 	api "github.com/GrigoriyPoshnagovInstitute/OrderService/api/server/orderinternal"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/infrastructure/health"
 )
 
-func NewInternalAPI() api.OrderInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(healthChecker health.Checker) api.OrderInternalServiceServer {
+	return &internalAPI{
+		healthChecker: healthChecker,
+	}
 }
 
 type internalAPI struct {
 	api.UnimplementedOrderInternalServiceServer
+
+	healthChecker health.Checker
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -19,3 +24,22 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) Health(ctx context.Context, _ *api.HealthRequest) (*api.HealthResponse, error) {
+	report := i.healthChecker.Check(ctx)
+
+	dependencies := make([]*api.DependencyHealth, 0, len(report.Dependencies))
+	for _, dependency := range report.Dependencies {
+		dependencies = append(dependencies, &api.DependencyHealth{
+			Name:       dependency.Name,
+			Status:     string(dependency.Status),
+			Error:      dependency.Error,
+			DurationMs: dependency.Duration.Milliseconds(),
+		})
+	}
+
+	return &api.HealthResponse{
+		Status:       string(report.Status),
+		Dependencies: dependencies,
+	}, nil
+}
@@ -5,10 +5,13 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 )
 
+const errorDomain = "order"
+
 type ErrorInterceptor struct {
 	Logger *log.Logger
 }
@@ -22,7 +25,15 @@ func (i ErrorInterceptor) TranslateGRPCError(err error) error {
 		return err
 	}
 
-	return status.Error(getGRPCCode(err), err.Error())
+	kind, _ := getErrorKind(err)
+	st := status.New(kind.code, err.Error())
+	if withDetails, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: kind.reason,
+		Domain: errorDomain,
+	}); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
 }
 
 func MakeLoggerServerInterceptor(logger *log.Logger) grpc.UnaryServerInterceptor {
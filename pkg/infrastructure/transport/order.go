@@ -0,0 +1,137 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	api "github.com/GrigoriyPoshnagovInstitute/OrderService/api/server/orderinternal"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+)
+
+var ErrInvalidOrderMessage = errors.New("invalid order message")
+
+var orderStatusToProto = map[model.OrderStatus]api.OrderStatus{
+	model.Open:      api.OrderStatus_ORDER_STATUS_OPEN,
+	model.Pending:   api.OrderStatus_ORDER_STATUS_PENDING,
+	model.Paid:      api.OrderStatus_ORDER_STATUS_PAID,
+	model.Cancelled: api.OrderStatus_ORDER_STATUS_CANCELLED,
+}
+
+func OrderToProto(order *model.Order) *api.Order {
+	items := make([]*api.Item, 0, len(order.Items))
+	for _, item := range order.Items {
+		var adjustments []*api.PriceAdjustment
+		for _, adjustment := range item.Adjustments {
+			adjustments = append(adjustments, &api.PriceAdjustment{
+				Reason: adjustment.Reason,
+				Amount: adjustment.Amount,
+			})
+		}
+		items = append(items, &api.Item{
+			Id:          item.ID.String(),
+			ProductId:   item.ProductID.String(),
+			Price:       item.Price,
+			Adjustments: adjustments,
+		})
+	}
+
+	msg := &api.Order{
+		Id:         order.ID.String(),
+		CustomerId: order.CustomerID.String(),
+		Status:     orderStatusToProto[order.Status],
+		Items:      items,
+		Tags:       order.Tags,
+		CreatedAt:  timestamppb.New(order.CreatedAt),
+		UpdatedAt:  timestamppb.New(order.UpdatedAt),
+	}
+	if order.DeletedAt != nil {
+		msg.DeletedAt = timestamppb.New(*order.DeletedAt)
+	}
+	return msg
+}
+
+func OrderFromProto(msg *api.Order) (*model.Order, error) {
+	orderID, err := parseUUID("id", msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+	customerID, err := parseUUID("customer_id", msg.GetCustomerId())
+	if err != nil {
+		return nil, err
+	}
+	status, err := orderStatusFromProto(msg.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.Item, 0, len(msg.GetItems()))
+	for _, item := range msg.GetItems() {
+		itemID, err := parseUUID("item.id", item.GetId())
+		if err != nil {
+			return nil, err
+		}
+		productID, err := parseUUID("item.product_id", item.GetProductId())
+		if err != nil {
+			return nil, err
+		}
+		var adjustments []model.PriceAdjustment
+		for _, adjustment := range item.GetAdjustments() {
+			adjustments = append(adjustments, model.PriceAdjustment{
+				Reason: adjustment.GetReason(),
+				Amount: adjustment.GetAmount(),
+			})
+		}
+		items = append(items, model.Item{
+			ID:          itemID,
+			ProductID:   productID,
+			Price:       item.GetPrice(),
+			Adjustments: adjustments,
+		})
+	}
+
+	var tags []string
+	for _, tag := range msg.GetTags() {
+		normalized, err := model.NormalizeTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tags: %q", ErrInvalidOrderMessage, tag)
+		}
+		tags = append(tags, normalized)
+	}
+	slices.Sort(tags)
+
+	order := &model.Order{
+		ID:         orderID,
+		CustomerID: customerID,
+		Status:     status,
+		Items:      items,
+		Tags:       slices.Compact(tags),
+		CreatedAt:  msg.GetCreatedAt().AsTime(),
+		UpdatedAt:  msg.GetUpdatedAt().AsTime(),
+	}
+	if msg.DeletedAt != nil {
+		deletedAt := msg.GetDeletedAt().AsTime()
+		order.DeletedAt = &deletedAt
+	}
+	return order, nil
+}
+
+func orderStatusFromProto(status api.OrderStatus) (model.OrderStatus, error) {
+	for modelStatus, protoStatus := range orderStatusToProto {
+		if protoStatus == status {
+			return modelStatus, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown status %s", ErrInvalidOrderMessage, status)
+}
+
+func parseUUID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %s: %v", ErrInvalidOrderMessage, field, err)
+	}
+	return id, nil
+}
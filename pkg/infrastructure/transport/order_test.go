@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	api "github.com/GrigoriyPoshnagovInstitute/OrderService/api/server/orderinternal"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/model"
+	"github.com/GrigoriyPoshnagovInstitute/OrderService/pkg/domain/modeltest"
+)
+
+func withAdjustments(order *model.Order) *model.Order {
+	order.Items[0].Adjustments = []model.PriceAdjustment{{Reason: "promo", Amount: -1.5}}
+	return order
+}
+
+func TestOrderProtoConversion(t *testing.T) {
+	t.Run("should round trip order through binary encoding", func(t *testing.T) {
+		updatedAt := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+		for _, order := range []*model.Order{
+			modeltest.NewOrderBuilder().WithStatus(model.Paid).WithItems(2).Build(),
+			modeltest.NewOrderBuilder().WithStatus(model.Cancelled).Build(),
+			withAdjustments(modeltest.NewOrderBuilder().WithItems(1).Build()),
+			modeltest.NewOrderBuilder().WithTags("fraud", "manual-review").Build(),
+			modeltest.NewOrderBuilder().WithUpdatedAt(updatedAt).Deleted().Build(),
+		} {
+			data, err := proto.Marshal(OrderToProto(order))
+			require.NoError(t, err)
+
+			var msg api.Order
+			require.NoError(t, proto.Unmarshal(data, &msg))
+			converted, err := OrderFromProto(&msg)
+			require.NoError(t, err)
+
+			require.Equal(t, order.ID, converted.ID)
+			require.Equal(t, order.CustomerID, converted.CustomerID)
+			require.Equal(t, order.Status, converted.Status)
+			require.Len(t, converted.Items, len(order.Items))
+			for i := range order.Items {
+				require.Equal(t, order.Items[i], converted.Items[i])
+			}
+			require.Equal(t, order.Tags, converted.Tags)
+			require.True(t, order.CreatedAt.Equal(converted.CreatedAt))
+			require.True(t, order.UpdatedAt.Equal(converted.UpdatedAt))
+			if order.DeletedAt == nil {
+				require.Nil(t, converted.DeletedAt)
+			} else {
+				require.True(t, order.DeletedAt.Equal(*converted.DeletedAt))
+			}
+		}
+	})
+
+	t.Run("should reject invalid messages", func(t *testing.T) {
+		valid := OrderToProto(modeltest.NewOrderBuilder().WithItems(1).Build())
+
+		invalidID := proto.Clone(valid).(*api.Order)
+		invalidID.Id = "not-a-uuid"
+		invalidStatus := proto.Clone(valid).(*api.Order)
+		invalidStatus.Status = api.OrderStatus_ORDER_STATUS_UNSPECIFIED
+		invalidItem := proto.Clone(valid).(*api.Order)
+		invalidItem.Items[0].ProductId = uuid.Nil.String()[:8]
+		invalidTag := proto.Clone(valid).(*api.Order)
+		invalidTag.Tags = []string{"manual/review"}
+
+		for _, msg := range []*api.Order{invalidID, invalidStatus, invalidItem, invalidTag} {
+			_, err := OrderFromProto(msg)
+			require.ErrorIs(t, err, ErrInvalidOrderMessage)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package errs
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const maxStackDepth = 32
+
+// Error keeps operation context of the wrapped error, errors.Is and errors.As see through it
+type Error struct {
+	Op      string
+	OrderID uuid.UUID
+	Err     error
+
+	stack []uintptr
+}
+
+// Wrap returns nil for nil error, so it can be used on every return path
+func Wrap(op string, orderID uuid.UUID, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Op: op, OrderID: orderID, Err: err}
+}
+
+// WrapWithStack is Wrap that also captures stack of the caller
+func WrapWithStack(op string, orderID uuid.UUID, err error) error {
+	if err == nil {
+		return nil
+	}
+	stack := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, stack)
+	return &Error{Op: op, OrderID: orderID, Err: err, stack: stack[:n]}
+}
+
+func (e *Error) Error() string {
+	if e.OrderID == uuid.Nil {
+		return e.Op + ": " + e.Err.Error()
+	}
+	return e.Op + " order=" + e.OrderID.String() + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StackTrace returns captured stack, empty when error was created without it
+func (e *Error) StackTrace() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// Format prints stack trace with %+v like github.com/pkg/errors does
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		_, _ = io.WriteString(s, e.Error())
+		if stack := e.StackTrace(); stack != "" {
+			_, _ = io.WriteString(s, "\n"+stack)
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		_, _ = io.WriteString(s, e.Error())
+	}
+}
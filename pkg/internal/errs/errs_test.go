@@ -0,0 +1,42 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var errSentinel = errors.New("sentinel")
+
+func TestWrap(t *testing.T) {
+	orderID := uuid.MustParse("01900000-0000-7000-8000-000000000001")
+
+	t.Run("should keep nil", func(t *testing.T) {
+		require.NoError(t, Wrap("op", orderID, nil))
+		require.NoError(t, WrapWithStack("op", orderID, nil))
+	})
+
+	t.Run("should add operation context and keep sentinel", func(t *testing.T) {
+		err := Wrap("orderService.AddItem", orderID, fmt.Errorf("find: %w", errSentinel))
+
+		require.ErrorIs(t, err, errSentinel)
+		require.EqualError(t, err, "orderService.AddItem order=01900000-0000-7000-8000-000000000001: find: sentinel")
+		require.EqualError(t, Wrap("orderService.CreateOrder", uuid.Nil, errSentinel), "orderService.CreateOrder: sentinel")
+
+		var wrapped *Error
+		require.ErrorAs(t, err, &wrapped)
+		require.Equal(t, orderID, wrapped.OrderID)
+		require.Empty(t, wrapped.StackTrace())
+	})
+
+	t.Run("should capture stack", func(t *testing.T) {
+		err := WrapWithStack("orderService.SetStatus", orderID, errSentinel)
+
+		require.ErrorIs(t, err, errSentinel)
+		require.Contains(t, fmt.Sprintf("%+v", err), "errs.TestWrap")
+		require.Equal(t, err.Error(), fmt.Sprintf("%v", err))
+	})
+}